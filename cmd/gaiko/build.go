@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/taikoxyz/gaiko/internal/builder"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/urfave/cli/v2"
+)
+
+var buildWitnessCommand = &cli.Command{
+	Name:  "build-witness",
+	Usage: "Build the witness of a batch or an L1 block from the RPC endpoints",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "network",
+			Usage: "Network of the block or batch",
+			Value: string(witness.TaikoMainnetNetwork),
+		},
+		&cli.StringFlag{
+			Name:  "l1-network",
+			Usage: "L1 network of a Taiko network",
+			Value: string(witness.EthereumNetwork),
+		},
+		&cli.StringFlag{
+			Name:  "rpc",
+			Usage: "RPC endpoint of the network, defaults to the one of its chain spec",
+		},
+		&cli.StringFlag{
+			Name:  "l1-rpc",
+			Usage: "RPC endpoint of the L1 network, defaults to the one of its chain spec",
+		},
+		&cli.StringFlag{
+			Name:  "beacon-rpc",
+			Usage: "Beacon API endpoint of the L1 network, defaults to the one of its chain spec",
+		},
+		&cli.Uint64Flag{
+			Name:  "batch-id",
+			Usage: "ID of the batch to build",
+		},
+		&cli.Uint64Flag{
+			Name:  "l1-inclusion-block",
+			Usage: "L1 block the batch is proposed in, looked up from the inbox if unset",
+		},
+		&cli.Uint64Flag{
+			Name:  "block",
+			Usage: "Number of the L1 block to build, instead of a batch",
+		},
+		&cli.StringFlag{
+			Name:  "prover",
+			Usage: "Address of the prover",
+		},
+		&cli.StringFlag{
+			Name:  "graffiti",
+			Usage: "Graffiti of the prover",
+		},
+		&cli.StringFlag{
+			Name:  "blob-proof-type",
+			Usage: `How blobs are verified, "kzg_versioned_hash" or "proof_of_equivalence"`,
+			Value: string(witness.KzgVersionedHash),
+		},
+		&cli.BoolFlag{
+			Name:  "flat",
+			Usage: "Keep the execution witness of geth instead of rebuilding the state tries",
+		},
+		&cli.IntFlag{
+			Name:  "parallelism",
+			Usage: "Maximum number of blocks fetched concurrently",
+			Value: 8,
+		},
+		&cli.StringFlag{
+			Name:  "out",
+			Usage: "`stdout` or file name of where to write the witness",
+			Value: "stdout",
+		},
+	},
+	Action: buildWitness,
+}
+
+func buildWitness(ctx *cli.Context) error {
+	chainSpec, err := witness.LookupChainSpec(witness.Network(ctx.String("network")))
+	if err != nil {
+		return err
+	}
+	cfg := &builder.Config{
+		ChainSpec: chainSpec,
+		RPC:       ctx.String("rpc"),
+		L1RPC:     ctx.String("l1-rpc"),
+		BeaconRPC: ctx.String("beacon-rpc"),
+		ProverData: witness.TaikoProverData{
+			Prover:   common.HexToAddress(ctx.String("prover")),
+			Graffiti: common.HexToHash(ctx.String("graffiti")),
+		},
+		BlobProofType: witness.BlobProofType(ctx.String("blob-proof-type")),
+		Flat:          ctx.Bool("flat"),
+		Parallelism:   ctx.Int("parallelism"),
+	}
+	if chainSpec.IsTaiko {
+		if cfg.L1ChainSpec, err = witness.LookupChainSpec(witness.Network(ctx.String("l1-network"))); err != nil {
+			return err
+		}
+	}
+	b, err := builder.New(ctx.Context, cfg)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	var input witness.WitnessInput
+	switch {
+	case ctx.IsSet("batch-id"):
+		input, err = b.BuildBatch(ctx.Context, ctx.Uint64("batch-id"), ctx.Uint64("l1-inclusion-block"))
+	case ctx.IsSet("block"):
+		input, err = b.BuildBlock(ctx.Context, ctx.Uint64("block"))
+	default:
+		return errors.New("expected a batch ID or a block")
+	}
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	if path := ctx.String("out"); path != "stdout" && path != "" {
+		return os.WriteFile(path, data, 0644)
+	}
+	_, err = ctx.App.Writer.Write(data)
+	return err
+}
@@ -10,3 +10,7 @@ import (
 func check(ctx context.Context, p prover.Prover, args *flags.Arguments) error {
 	return p.Check(ctx, args)
 }
+
+func witnessKey(ctx context.Context, p prover.Prover, args *flags.Arguments) error {
+	return p.WitnessKey(ctx, args)
+}
@@ -28,6 +28,11 @@ var oneshotCommand = &cli.Command{
 	Flags: []cli.Flag{
 		flags.SGXInstanceIDFlag,
 		flags.WitnessFlag,
+		flags.WitnessFormatFlag,
+		flags.MaxWitnessSizeFlag,
+		flags.StrictWitnessFlag,
+		flags.WitnessEncryptedFlag,
+		flags.ParallelismFlag,
 		flags.ProofFlag,
 	},
 }
@@ -39,6 +44,27 @@ var batchOneshotCommand = &cli.Command{
 	Flags: []cli.Flag{
 		flags.SGXInstanceIDFlag,
 		flags.WitnessFlag,
+		flags.WitnessFormatFlag,
+		flags.MaxWitnessSizeFlag,
+		flags.StrictWitnessFlag,
+		flags.WitnessEncryptedFlag,
+		flags.ParallelismFlag,
+		flags.ProofFlag,
+	},
+}
+
+var l1BlockOneshotCommand = &cli.Command{
+	Name:   "one-l1-block-shot",
+	Usage:  "Run an Ethereum block state transition once",
+	Action: withSGX(l1BlockOneshot),
+	Flags: []cli.Flag{
+		flags.SGXInstanceIDFlag,
+		flags.WitnessFlag,
+		flags.WitnessFormatFlag,
+		flags.MaxWitnessSizeFlag,
+		flags.StrictWitnessFlag,
+		flags.WitnessEncryptedFlag,
+		flags.ParallelismFlag,
 		flags.ProofFlag,
 	},
 }
@@ -69,6 +95,26 @@ var checkCommand = &cli.Command{
 	Action: withSGX(check),
 }
 
+var witnessKeyCommand = &cli.Command{
+	Name:   "witness-key",
+	Usage:  "Print the key to encrypt the witnesses to, with its quote",
+	Action: withSGX(witnessKey),
+	Flags: []cli.Flag{
+		flags.ProofFlag,
+	},
+}
+
+var witnessCommand = &cli.Command{
+	Name:  "witness",
+	Usage: "Inspect witness data",
+	Subcommands: []*cli.Command{
+		witnessDiffCommand,
+		witnessInspectCommand,
+		witnessCheckCommand,
+		witnessEncryptCommand,
+	},
+}
+
 var serverCommand = &cli.Command{
 	Name:    "server",
 	Aliases: []string{"serve", "s"},
@@ -81,6 +127,9 @@ var serverCommand = &cli.Command{
 			Usage:   "Listening on port",
 		},
 		flags.SGXInstanceIDFlag,
+		flags.MaxWitnessSizeFlag,
+		flags.StrictWitnessFlag,
+		flags.ParallelismFlag,
 	},
 	Action: runServer,
 }
@@ -103,9 +152,13 @@ func init() {
 	app.Commands = []*cli.Command{
 		oneshotCommand,
 		batchOneshotCommand,
+		l1BlockOneshotCommand,
 		aggregateCommand,
 		bootstrapCommand,
 		checkCommand,
+		witnessKeyCommand,
+		witnessCommand,
+		buildWitnessCommand,
 		serverCommand,
 	}
 	app.Before = flags.InitLogger
@@ -11,6 +11,10 @@ func oneshot(ctx context.Context, p prover.Prover, args *flags.Arguments) error
 	return p.Oneshot(ctx, args)
 }
 
+func l1BlockOneshot(ctx context.Context, p prover.Prover, args *flags.Arguments) error {
+	return p.L1BlockOneshot(ctx, args)
+}
+
 func batchOneshot(ctx context.Context, p prover.Prover, args *flags.Arguments) error {
 	return p.BatchOneshot(ctx, args)
 }
@@ -16,9 +16,15 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/gaiko/internal/flags"
 	"github.com/taikoxyz/gaiko/internal/prover"
+	"github.com/taikoxyz/gaiko/pkg/compress"
 	"github.com/urfave/cli/v2"
 )
 
+const (
+	witnessEncryptionHeader = "X-Witness-Encryption"
+	witnessEncryptionECIES  = "ecies"
+)
+
 var bytesBufferPool = sync.Pool{
 	New: func() any {
 		return new(bytes.Buffer)
@@ -26,7 +32,7 @@ var bytesBufferPool = sync.Pool{
 }
 
 type ProveData struct {
-	ProveMode string `json:"prove_mode"` // block, batch, aggregation
+	ProveMode string `json:"prove_mode"` // block, batch, l1-block, aggregation
 	Input     []byte `json:"input,omitempty"`
 }
 
@@ -42,9 +48,11 @@ const (
 	Unknown       ProveMode = "unknown"
 	OntakeBlock   ProveMode = "block"
 	PacayaBatch   ProveMode = "batch"
+	L1Block       ProveMode = "l1-block"
 	Aggregation   ProveMode = "aggregate"
 	Bootstrap     ProveMode = "bootstrap"
 	StatusCheck   ProveMode = "check"
+	WitnessKey    ProveMode = "witness-key"
 	TestHeartBeat ProveMode = "heartbeat"
 	HeklaBlock    ProveMode = "hekla" // deprecated
 )
@@ -63,9 +71,14 @@ func recoverMiddleware(next http.Handler) http.Handler {
 
 func proveHandler(ctx context.Context, args *flags.Arguments, sgxProver *prover.SGXProver, w http.ResponseWriter, r *http.Request, proveMode ProveMode) {
 	contentType := r.Header.Get("Content-Type")
-	if contentType != "application/json" {
+	switch contentType {
+	case "application/json":
+		args.WitnessFormat = flags.JSONWitnessFormat
+	case "application/x-gaiko-witness":
+		args.WitnessFormat = flags.GaikoBinaryWitnessFormat
+	default:
 		fmt.Printf("Prove recievied content type: %s\n", contentType)
-		http.Error(w, "Content-Type must be application/json", http.StatusBadRequest)
+		http.Error(w, "Content-Type must be application/json or application/x-gaiko-witness", http.StatusBadRequest)
 		return
 	}
 
@@ -81,12 +94,16 @@ func proveHandler(ctx context.Context, args *flags.Arguments, sgxProver *prover.
 		return
 	case PacayaBatch:
 		err = batchOneshot(ctx, sgxProver, args)
+	case L1Block:
+		err = l1BlockOneshot(ctx, sgxProver, args)
 	case Aggregation:
 		err = aggregate(ctx, sgxProver, args)
 	case Bootstrap:
 		err = bootstrap(ctx, sgxProver, args)
 	case StatusCheck:
 		err = check(ctx, sgxProver, args)
+	case WitnessKey:
+		err = witnessKey(ctx, sgxProver, args)
 	default:
 		http.Error(w, "Unknown prove mode", http.StatusBadRequest)
 		return
@@ -135,7 +152,23 @@ func runServer(c *cli.Context) error {
 		buf.Reset()
 		args.ProofWriter = buf
 		defer bytesBufferPool.Put(args.ProofWriter)
-		args.WitnessReader = r.Body
+		body, err := compress.NewEncodingReader(r.Body, r.Header.Get("Content-Encoding"), args.MaxWitnessSize)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+			return
+		}
+		defer body.Close()
+		args.WitnessReader = body
+		// the body is decrypted inside the enclave, the host only sees the
+		// ciphertext
+		switch encryption := r.Header.Get(witnessEncryptionHeader); encryption {
+		case "":
+		case witnessEncryptionECIES:
+			args.WitnessEncrypted = true
+		default:
+			http.Error(w, "unsupported witness encryption: "+encryption, http.StatusUnsupportedMediaType)
+			return
+		}
 		sgxProver := prover.NewSGXProver(args)
 		proveMode := Unknown
 		if r.URL.Query().Get("debug") == "true" {
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/taikoxyz/gaiko/internal/flags"
+	"github.com/taikoxyz/gaiko/internal/prover"
+	"github.com/taikoxyz/gaiko/internal/transition"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/urfave/cli/v2"
+)
+
+var witnessDiffCommand = &cli.Command{
+	Name:      "diff",
+	Usage:     "Compare the state, contracts and headers of two witnesses",
+	ArgsUsage: "<witness> <witness>",
+	Flags: []cli.Flag{
+		flags.WitnessFormatFlag,
+	},
+	Action: witnessDiff,
+}
+
+func witnessDiff(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return errors.New("expected two witness files")
+	}
+	format := ctx.String(flags.WitnessFormatFlag.Name)
+	a, err := readWitness(ctx.Args().Get(0), format)
+	if err != nil {
+		return err
+	}
+	b, err := readWitness(ctx.Args().Get(1), format)
+	if err != nil {
+		return err
+	}
+	diffs, err := witness.Diff(a, b)
+	if err != nil {
+		return err
+	}
+	for _, diff := range diffs {
+		fmt.Fprintln(ctx.App.Writer, diff)
+	}
+	if len(diffs) != 0 {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+func readWitness(path, format string) (witness.WitnessInput, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	input, err := decodeWitness(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return input, nil
+}
+
+// decodeWitness decodes a single or batch witness in the given format. A JSON
+// batch witness is recognized by its `inputs` field, a binary one by decoding
+// as a batch first.
+func decodeWitness(data []byte, format string) (witness.WitnessInput, error) {
+	switch format {
+	case flags.GaikoBinaryWitnessFormat:
+		batch := &witness.BatchGuestInput{}
+		if err := batch.UnmarshalBinary(data); err == nil {
+			return batch, nil
+		}
+		input := &witness.GuestInput{}
+		if err := input.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return input, nil
+	case flags.JSONWitnessFormat, "":
+	default:
+		return nil, fmt.Errorf("unknown witness format: %s", format)
+	}
+	var probe struct {
+		Inputs json.RawMessage `json:"inputs"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	var input witness.WitnessInput = &witness.GuestInput{}
+	if probe.Inputs != nil {
+		input = &witness.BatchGuestInput{}
+	}
+	if err := json.Unmarshal(data, input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+var witnessInspectCommand = &cli.Command{
+	Name:  "inspect",
+	Usage: "Print a summary of a witness without proving it",
+	Flags: []cli.Flag{
+		flags.WitnessFlag,
+		flags.WitnessFormatFlag,
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Output format: table or json",
+			Value: "table",
+		},
+		&cli.IntFlag{
+			Name:  "top",
+			Usage: "Number of the heaviest accounts to list",
+			Value: 10,
+		},
+	},
+	Action: witnessInspect,
+}
+
+func witnessInspect(ctx *cli.Context) error {
+	format := ctx.String("format")
+	if format != "table" && format != "json" {
+		return fmt.Errorf("unknown format: %s", format)
+	}
+	top := ctx.Int("top")
+	if top < 0 {
+		return fmt.Errorf("invalid top: %d", top)
+	}
+	args := flags.NewArguments(ctx)
+	data, err := io.ReadAll(args.WitnessReader)
+	if err != nil {
+		return err
+	}
+	input, err := decodeWitness(data, args.WitnessFormat)
+	if err != nil {
+		return err
+	}
+	summary, err := witness.Inspect(input, top)
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		enc := json.NewEncoder(ctx.App.Writer)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summary)
+	}
+	return printSummary(ctx.App.Writer, summary)
+}
+
+func printSummary(out io.Writer, s *witness.Summary) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "chain\t%s (%d)\n", s.Chain, s.ChainID)
+	fmt.Fprintf(w, "hard fork\t%s\n", s.HardFork)
+	if s.BatchID != 0 {
+		fmt.Fprintf(w, "batch\t%d\n", s.BatchID)
+	}
+	fmt.Fprintf(w, "blocks\t%d\n", len(s.Blocks))
+	fmt.Fprintf(w, "ancestor headers\t%d..%d\n", s.AncestorHeaders.From, s.AncestorHeaders.To)
+	fmt.Fprintf(w, "calldata\t%d bytes\n", s.CalldataSize)
+	for i, blob := range s.Blobs {
+		fmt.Fprintf(w, "blob %d\t%d bytes, %d bytes of data\n", i, blob.Size, blob.DataSize)
+	}
+
+	fmt.Fprintln(w, "\nBLOCK\tHASH\tBODY TXS\tDECODED TXS\tSTATE NODES\tSTATE BYTES\tSTORAGE NODES\tSTORAGE BYTES")
+	for _, b := range s.Blocks {
+		fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\t%d\t%d\t%d\n",
+			b.Number, b.Hash, b.BodyTxs, b.DecodedTxs,
+			b.StateTrie.Nodes, b.StateTrie.Bytes, b.Storage.Nodes, b.Storage.Bytes)
+	}
+
+	fmt.Fprintln(w, "\nBLOCK\tACCOUNT\tSLOTS\tNODES\tBYTES")
+	for _, a := range s.Accounts {
+		fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\n", a.Block, a.Address, a.Slots, a.Trie.Nodes, a.Trie.Bytes)
+	}
+
+	fmt.Fprintln(w, "\nCODE HASH\tSIZE")
+	for _, c := range s.Contracts {
+		fmt.Fprintf(w, "%s\t%d\n", c.Hash, c.Size)
+	}
+	return w.Flush()
+}
+
+var witnessCheckCommand = &cli.Command{
+	Name:  "check",
+	Usage: "Execute a witness and report the state it lacks or doesn't use",
+	Flags: []cli.Flag{
+		flags.WitnessFlag,
+		flags.WitnessFormatFlag,
+	},
+	Action: witnessCheck,
+}
+
+func witnessCheck(ctx *cli.Context) error {
+	args := flags.NewArguments(ctx)
+	data, err := io.ReadAll(args.WitnessReader)
+	if err != nil {
+		return err
+	}
+	input, err := decodeWitness(data, args.WitnessFormat)
+	if err != nil {
+		return err
+	}
+	reports, err := transition.Check(input)
+	if err != nil {
+		return err
+	}
+	failed := false
+	out := ctx.App.Writer
+	for _, r := range reports {
+		fmt.Fprintf(out, "block %d\n", r.Block)
+		for _, addr := range r.MissingAccounts {
+			fmt.Fprintf(out, "  missing account %s\n", addr)
+		}
+		for _, slot := range r.MissingSlots {
+			fmt.Fprintf(out, "  missing slot %s of %s\n", slot.Slot, slot.Address)
+		}
+		for _, hash := range r.MissingCodes {
+			fmt.Fprintf(out, "  missing code %s\n", hash)
+		}
+		for _, num := range r.MissingHeaders {
+			fmt.Fprintf(out, "  missing header %d\n", num)
+		}
+		for _, hash := range r.UnusedNodes {
+			fmt.Fprintf(out, "  unused node %s\n", hash)
+		}
+		for _, hash := range r.UnusedContracts {
+			fmt.Fprintf(out, "  unused code %s\n", hash)
+		}
+		for _, num := range r.UnusedHeaders {
+			fmt.Fprintf(out, "  unused header %d\n", num)
+		}
+		if r.Err != nil {
+			fmt.Fprintf(out, "  execution failed: %v\n", r.Err)
+		}
+		if !r.Complete() || r.Err != nil {
+			failed = true
+		}
+	}
+	if failed {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+var witnessEncryptCommand = &cli.Command{
+	Name:  "encrypt",
+	Usage: "Encrypt a witness to the witness key of an instance",
+	Flags: []cli.Flag{
+		flags.WitnessFlag,
+		&cli.StringFlag{
+			Name:     "key",
+			Usage:    "Witness key of the instance, as printed by witness-key",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "out",
+			Usage: "`stdout` or file name of where to write the encrypted witness",
+			Value: "stdout",
+		},
+	},
+	Action: witnessEncrypt,
+}
+
+func witnessEncrypt(ctx *cli.Context) error {
+	key, err := hexutil.Decode(ctx.String("key"))
+	if err != nil {
+		return err
+	}
+	pubKey, err := crypto.UnmarshalPubkey(key)
+	if err != nil {
+		return err
+	}
+	// the witness is encrypted as it is, compressed or not
+	var data []byte
+	if path := ctx.String(flags.WitnessFlag.Name); path == "stdin" || path == "" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+	enc, err := prover.EncryptWitness(pubKey, data)
+	if err != nil {
+		return err
+	}
+	if path := ctx.String("out"); path != "stdout" && path != "" {
+		return os.WriteFile(path, enc, 0644)
+	}
+	_, err = ctx.App.Writer.Write(enc)
+	return err
+}
@@ -9,6 +9,7 @@ require (
 	github.com/fjl/gencodec v0.1.1
 	github.com/google/go-tdx-guest v0.3.1
 	github.com/holiman/uint256 v1.3.2
+	github.com/klauspost/compress v1.18.0
 	github.com/stretchr/testify v1.10.0
 	github.com/taikoxyz/taiko-mono v0.0.0-20250919040801-b26bc33100c7
 	github.com/urfave/cli/v2 v2.27.5
@@ -55,7 +56,6 @@ require (
 	github.com/gorilla/websocket v1.5.3 // indirect
 	github.com/holiman/bloomfilter/v2 v2.0.3 // indirect
 	github.com/joho/godotenv v1.5.1 // indirect
-	github.com/klauspost/compress v1.18.0 // indirect
 	github.com/mattn/go-runewidth v0.0.16 // indirect
 	github.com/mmcloughlin/addchain v0.4.0 // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
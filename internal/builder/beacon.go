@@ -0,0 +1,107 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/taikoxyz/gaiko/internal/witness"
+)
+
+// blobSidecar is an item of the beacon `blob_sidecars` response.
+type blobSidecar struct {
+	Index         string        `json:"index"`
+	Blob          hexutil.Bytes `json:"blob"`
+	KZGCommitment hexutil.Bytes `json:"kzg_commitment"`
+	KZGProof      hexutil.Bytes `json:"kzg_proof"`
+}
+
+// fetchBlobs fills the blobs of a batch, with their commitments and proofs,
+// from the sidecars of the L1 block they were created in.
+func (b *Builder) fetchBlobs(ctx context.Context, taiko *witness.TaikoGuestBatchInput, fork *witness.PacayaBlockProposed) error {
+	if b.beacon == "" {
+		return errors.New("missing beacon endpoint")
+	}
+	l1 := b.cfg.L1ChainSpec
+	if l1.SecondsPerSlot == 0 {
+		return fmt.Errorf("no slot time for %s", l1.Name)
+	}
+	header, err := b.headerByNumber(ctx, fork.BlobCreatedIn())
+	if err != nil {
+		return fmt.Errorf("blob block %d: %w", fork.BlobCreatedIn(), err)
+	}
+	slot := (header.Time - l1.GenesisTime) / l1.SecondsPerSlot
+	sidecars, err := b.blobSidecars(ctx, slot)
+	if err != nil {
+		return fmt.Errorf("blob sidecars of slot %d: %w", slot, err)
+	}
+
+	hashes := fork.BlobHashes()
+	var (
+		blobs       = make([][eth.BlobSize]byte, len(hashes))
+		commitments = make([][48]byte, len(hashes))
+		proofs      = make([][48]byte, len(hashes))
+	)
+	for i, hash := range hashes {
+		found := false
+		for _, sidecar := range sidecars {
+			var commitment kzg4844.Commitment
+			if len(sidecar.KZGCommitment) != len(commitment) {
+				continue
+			}
+			copy(commitment[:], sidecar.KZGCommitment)
+			if eth.KZGToVersionedHash(commitment) != common.Hash(hash) {
+				continue
+			}
+			if len(sidecar.Blob) != eth.BlobSize || len(sidecar.KZGProof) != len(proofs[i]) {
+				return fmt.Errorf("malformed sidecar of blob %#x", hash)
+			}
+			copy(blobs[i][:], sidecar.Blob)
+			commitments[i] = commitment
+			copy(proofs[i][:], sidecar.KZGProof)
+			found = true
+			break
+		}
+		if !found {
+			return fmt.Errorf("blob %#x not found in slot %d", hash, slot)
+		}
+	}
+	taiko.TxDataFromBlob = blobs
+	taiko.BlobCommitments = &commitments
+	taiko.BlobProofs = &proofs
+	return nil
+}
+
+func (b *Builder) blobSidecars(ctx context.Context, slot uint64) ([]*blobSidecar, error) {
+	url := fmt.Sprintf("%s/eth/v1/beacon/blob_sidecars/%d", b.beacon, slot)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	client := b.cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("beacon responded %s", resp.Status)
+	}
+	var res struct {
+		Data []*blobSidecar `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
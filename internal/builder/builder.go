@@ -0,0 +1,266 @@
+// Package builder assembles witnesses from the JSON-RPC of the chains and the
+// beacon API of L1, without a raiko host.
+package builder
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultParallelism = 8
+	// maxAnchorHeightOffset is how many L1 blocks after its anchor block a
+	// Pacaya batch can be proposed in.
+	maxAnchorHeightOffset = 64
+)
+
+// Config selects the chains a witness is built for and where it's fetched
+// from. The endpoints default to those of the chain specs.
+type Config struct {
+	ChainSpec   *witness.ChainSpec
+	L1ChainSpec *witness.ChainSpec
+	RPC         string
+	L1RPC       string
+	BeaconRPC   string
+
+	ProverData    witness.TaikoProverData
+	BlobProofType witness.BlobProofType
+	// Flat keeps the execution witness of the blocks instead of rebuilding
+	// their state tries.
+	Flat bool
+	// Parallelism is the number of blocks fetched concurrently, 0 means 8.
+	Parallelism int
+	// HTTPClient is used for the beacon API, http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+// Builder fetches the parts of a witness from the endpoints of Config.
+type Builder struct {
+	cfg    *Config
+	l2     *rpc.Client
+	l1     *rpc.Client
+	beacon string
+}
+
+func New(ctx context.Context, cfg *Config) (*Builder, error) {
+	if cfg.ChainSpec == nil {
+		return nil, errors.New("missing chain spec")
+	}
+	l2, err := rpc.DialContext(ctx, cmp.Or(cfg.RPC, cfg.ChainSpec.RPC))
+	if err != nil {
+		return nil, err
+	}
+	b := &Builder{cfg: cfg, l2: l2, beacon: cfg.BeaconRPC}
+	if l1 := cfg.L1ChainSpec; l1 != nil {
+		if b.l1, err = rpc.DialContext(ctx, cmp.Or(cfg.L1RPC, l1.RPC)); err != nil {
+			l2.Close()
+			return nil, err
+		}
+		if b.beacon == "" && l1.BeaconRPC != nil {
+			b.beacon = *l1.BeaconRPC
+		}
+	}
+	b.beacon = strings.TrimSuffix(b.beacon, "/")
+	return b, nil
+}
+
+func (b *Builder) Close() {
+	b.l2.Close()
+	if b.l1 != nil {
+		b.l1.Close()
+	}
+}
+
+// BuildBlock builds the witness of a block of a chain that isn't Taiko, the
+// blocks of Taiko are proven by batch.
+func (b *Builder) BuildBlock(ctx context.Context, number uint64) (*witness.L1BlockGuestInput, error) {
+	if b.cfg.ChainSpec.IsTaiko {
+		return nil, fmt.Errorf("blocks of %s are built by batch", b.cfg.ChainSpec.Name)
+	}
+	g, err := b.buildInput(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	g.Taiko = &witness.TaikoGuestInput{
+		TxData:        []byte{},
+		BlockProposed: &witness.NotingBlockProposed{},
+		ProverData:    b.proverData(),
+		BlobProofType: b.cfg.BlobProofType,
+	}
+	return &witness.L1BlockGuestInput{GuestInput: *g}, nil
+}
+
+// BuildBatch builds the witness of a Pacaya batch, proposed in the L1 block
+// l1InclusionBlock. A zero l1InclusionBlock looks the block up from the inbox.
+// Shasta proposals aren't supported.
+func (b *Builder) BuildBatch(ctx context.Context, batchID, l1InclusionBlock uint64) (*witness.BatchGuestInput, error) {
+	if b.l1 == nil {
+		return nil, errors.New("missing L1 chain spec")
+	}
+	proposed, err := b.batchProposed(ctx, batchID, l1InclusionBlock)
+	if err != nil {
+		return nil, err
+	}
+	if b.cfg.ChainSpec.ActiveSpecID(proposed.Info.LastBlockId) == witness.ShastaSpecID {
+		return nil, fmt.Errorf("batch %d is a Shasta proposal, only Pacaya batches can be built", batchID)
+	}
+	fork := witness.NewPacayaBlockProposed(proposed)
+	l1Header, err := b.headerByNumber(ctx, proposed.Info.AnchorBlockId)
+	if err != nil {
+		return nil, fmt.Errorf("anchor block %d: %w", proposed.Info.AnchorBlockId, err)
+	}
+	txList := proposed.TxList
+	if txList == nil {
+		txList = []byte{}
+	}
+	taiko := &witness.TaikoGuestBatchInput{
+		BatchID:            batchID,
+		L1Header:           l1Header,
+		BatchProposed:      fork,
+		ChainSpec:          b.cfg.ChainSpec,
+		ProverData:         b.proverData(),
+		TxDataFromCalldata: txList,
+		TxDataFromBlob:     [][eth.BlobSize]byte{},
+		BlobProofType:      b.cfg.BlobProofType,
+	}
+	if fork.BlobUsed() {
+		if err := b.fetchBlobs(ctx, taiko, fork); err != nil {
+			return nil, err
+		}
+	}
+
+	first, last := fork.BlockNumber(), proposed.Info.LastBlockId
+	inputs := make([]*witness.GuestInput, last-first+1)
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(cmp.Or(b.cfg.Parallelism, defaultParallelism))
+	for i := range inputs {
+		eg.Go(func() error {
+			g, err := b.buildInput(ctx, first+uint64(i))
+			if err != nil {
+				return err
+			}
+			txs := g.Block.Transactions()
+			if len(txs) == 0 {
+				return fmt.Errorf("block %d without anchor transaction", g.Block.NumberU64())
+			}
+			g.Taiko = &witness.TaikoGuestInput{
+				L1Header:      l1Header,
+				TxData:        []byte{},
+				AnchorTx:      txs[0],
+				BlockProposed: fork,
+				ProverData:    b.proverData(),
+				BlobProofType: b.cfg.BlobProofType,
+			}
+			inputs[i] = g
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return &witness.BatchGuestInput{Inputs: inputs, Taiko: taiko}, nil
+}
+
+func (b *Builder) proverData() *witness.TaikoProverData {
+	data := b.cfg.ProverData
+	return &data
+}
+
+// batchProposed finds the BatchProposed event of a batch in the logs of the
+// inbox in an L1 block. Without the block, the event is searched for in the
+// blocks the batch can be proposed in after its anchor block.
+func (b *Builder) batchProposed(ctx context.Context, batchID, l1Block uint64) (*pacaya.TaikoInboxClientBatchProposed, error) {
+	inbox := b.cfg.ChainSpec.L1Contract
+	if inbox == nil {
+		return nil, fmt.Errorf("no L1 contract for %s", b.cfg.ChainSpec.Name)
+	}
+	client := ethclient.NewClient(b.l1)
+	from, to := l1Block, l1Block
+	if l1Block == 0 {
+		caller, err := pacaya.NewTaikoInboxClientCaller(*inbox, client)
+		if err != nil {
+			return nil, err
+		}
+		batch, err := caller.GetBatch(&bind.CallOpts{Context: ctx}, batchID)
+		if err != nil {
+			return nil, fmt.Errorf("batch %d: %w", batchID, err)
+		}
+		from, to = batch.AnchorBlockId, batch.AnchorBlockId+maxAnchorHeightOffset
+	}
+	filterer, err := pacaya.NewTaikoInboxClientFilterer(*inbox, nil)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{*inbox},
+		Topics:    [][]common.Hash{{encoding.TaikoInboxABI.Events["BatchProposed"].ID}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range logs {
+		proposed, err := filterer.ParseBatchProposed(log)
+		if err != nil {
+			return nil, err
+		}
+		if proposed.Meta.BatchId == batchID {
+			return proposed, nil
+		}
+	}
+	return nil, fmt.Errorf("batch %d isn't proposed in L1 blocks %d to %d", batchID, from, to)
+}
+
+// buildInput fetches a block and its execution witness, the Taiko part is left
+// to the caller.
+func (b *Builder) buildInput(ctx context.Context, number uint64) (*witness.GuestInput, error) {
+	block, err := ethclient.NewClient(b.l2).BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("block %d: %w", number, err)
+	}
+	var ew witness.ExecutionWitness
+	if err := b.l2.CallContext(ctx, &ew, "debug_executionWitness", hexutil.Uint64(number)); err != nil {
+		return nil, fmt.Errorf("execution witness of block %d: %w", number, err)
+	}
+	if len(ew.Headers) == 0 || ew.Headers[0].Hash() != block.ParentHash() {
+		return nil, fmt.Errorf("execution witness of block %d doesn't start at its parent", number)
+	}
+	g := &witness.GuestInput{
+		Block:           block,
+		ChainSpec:       b.cfg.ChainSpec,
+		ParentHeader:    ew.Headers[0],
+		AncestorHeaders: ew.Headers[1:],
+	}
+	if b.cfg.Flat {
+		g.ExecutionWitness = &ew
+		return g, nil
+	}
+	if err := b.buildState(ctx, g, &ew); err != nil {
+		return nil, fmt.Errorf("state of block %d: %w", number, err)
+	}
+	return g, nil
+}
+
+// headerByNumber fetches an L1 header.
+func (b *Builder) headerByNumber(ctx context.Context, number uint64) (*types.Header, error) {
+	return ethclient.NewClient(b.l1).HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+}
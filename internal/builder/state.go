@@ -0,0 +1,158 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/gaiko/pkg/mpt"
+)
+
+// accountProof is the result of `eth_getProof`, only the nodes are kept.
+type accountProof struct {
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []struct {
+		Proof []hexutil.Bytes `json:"proof"`
+	} `json:"storageProof"`
+}
+
+// buildState rebuilds the parent state tries of g from an execution witness.
+// The keys of the witness tell which accounts and slots are read, their proofs
+// at the parent block are added to the nodes of the witness so that the tries
+// also cover the paths the block writes.
+func (b *Builder) buildState(ctx context.Context, g *witness.GuestInput, ew *witness.ExecutionWitness) error {
+	if len(ew.Keys) == 0 {
+		return errors.New("execution witness without keys, build it flat")
+	}
+	nodes := mpt.NewNodeSet()
+	for _, node := range ew.State {
+		nodes[keccak.Keccak(node)] = node
+	}
+	root := g.ParentHeader.Root
+	stateTrie, err := resolveTrie(root, nodes)
+	if err != nil {
+		return err
+	}
+
+	var (
+		addrs []common.Address
+		slots []common.Hash
+	)
+	for _, key := range ew.Keys {
+		switch len(key) {
+		case common.AddressLength:
+			addrs = append(addrs, common.BytesToAddress(key))
+		case common.HashLength:
+			slots = append(slots, common.BytesToHash(key))
+		default:
+			return fmt.Errorf("execution witness key of %d bytes", len(key))
+		}
+	}
+
+	storageRoots := make(map[common.Address]common.Hash, len(addrs))
+	accountSlots := make(map[common.Address][]common.Hash, len(addrs))
+	for _, addr := range addrs {
+		acc, err := account(stateTrie, addr)
+		if err != nil {
+			return fmt.Errorf("account %#x: %w", addr, err)
+		}
+		storageRoots[addr] = acc.Root
+		if acc.Root == types.EmptyRootHash {
+			continue
+		}
+		// the keys don't say whose slots they are, a slot belongs to the
+		// storage that holds a value for it. An absent slot reads as nil in
+		// every resolved storage, the nodes proving its absence are already
+		// part of the execution witness.
+		storage, err := resolveTrie(acc.Root, nodes)
+		if err != nil {
+			return err
+		}
+		for _, slot := range slots {
+			if value, err := storage.Get(keccak.Keccak(slot.Bytes()).Bytes()); err == nil && value != nil {
+				accountSlots[addr] = append(accountSlots[addr], slot)
+			}
+		}
+	}
+
+	parent := hexutil.EncodeBig(g.ParentHeader.Number)
+	for _, addr := range addrs {
+		keys := accountSlots[addr]
+		if keys == nil {
+			keys = []common.Hash{}
+		}
+		var proof accountProof
+		if err := b.l2.CallContext(ctx, &proof, "eth_getProof", addr, keys, parent); err != nil {
+			return fmt.Errorf("proof of %#x: %w", addr, err)
+		}
+		if proof.StorageHash != storageRoots[addr] {
+			return fmt.Errorf("storage root mismatch for %#x: expected %#x, got %#x",
+				addr, storageRoots[addr], proof.StorageHash)
+		}
+		for _, node := range proof.AccountProof {
+			nodes[keccak.Keccak(node)] = node
+		}
+		for _, slot := range proof.StorageProof {
+			for _, node := range slot.Proof {
+				nodes[keccak.Keccak(node)] = node
+			}
+		}
+	}
+
+	if g.ParentStateTrie, err = resolveTrie(root, nodes); err != nil {
+		return err
+	}
+	g.ParentStorage = make(map[common.Address]*witness.StorageEntry, len(addrs))
+	for _, addr := range addrs {
+		storage, err := resolveTrie(storageRoots[addr], nodes)
+		if err != nil {
+			return err
+		}
+		entry := &witness.StorageEntry{Trie: storage, Slots: []*big.Int{}}
+		for _, slot := range accountSlots[addr] {
+			entry.Slots = append(entry.Slots, new(big.Int).SetBytes(slot.Bytes()))
+		}
+		g.ParentStorage[addr] = entry
+	}
+	g.Contracts = make([][]byte, len(ew.Codes))
+	for i, code := range ew.Codes {
+		g.Contracts[i] = code
+	}
+	return nil
+}
+
+// resolveTrie resolves the trie of root as far as nodes go.
+func resolveTrie(root common.Hash, nodes mpt.NodeSet) (*mpt.MptNode, error) {
+	trie, err := mpt.FromProofs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := trie.Resolve(nodes); err != nil {
+		return nil, err
+	}
+	return trie, nil
+}
+
+// account reads an account from the state trie, a missing one is empty.
+func account(trie *mpt.MptNode, addr common.Address) (*types.StateAccount, error) {
+	data, err := trie.Get(keccak.Keccak(addr.Bytes()).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return types.NewEmptyStateAccount(), nil
+	}
+	acc := new(types.StateAccount)
+	if err := rlp.DecodeBytes(data, acc); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
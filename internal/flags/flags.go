@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/pkg/compress"
 	"github.com/urfave/cli/v2"
 )
 
@@ -53,6 +54,33 @@ var (
 		Value: stdinSelector,
 	}
 
+	WitnessFormatFlag = &cli.StringFlag{
+		Name:  "witness-format",
+		Usage: `Encoding of the witness data, "json" or "gaiko-binary" (gaiko's own encoding, not raiko's)`,
+		Value: JSONWitnessFormat,
+	}
+
+	MaxWitnessSizeFlag = &cli.Int64Flag{
+		Name:  "max-witness-size",
+		Usage: "Maximum size in bytes of a compressed witness once decompressed",
+		Value: DefaultMaxWitnessSize,
+	}
+
+	StrictWitnessFlag = &cli.BoolFlag{
+		Name:  "strict-witness",
+		Usage: "Reject JSON witnesses with unknown fields or missing required ones, the witness is read whole before decoding instead of streamed",
+	}
+
+	WitnessEncryptedFlag = &cli.BoolFlag{
+		Name:  "witness-encrypted",
+		Usage: "The witness data is encrypted to the witness key of the instance",
+	}
+
+	ParallelismFlag = &cli.IntFlag{
+		Name:  "parallelism",
+		Usage: "Maximum number of blocks verified concurrently, 0 means the number of CPUs",
+	}
+
 	ProofFlag = &cli.StringFlag{
 		Name:  "proof",
 		Usage: "`stdout` or file name of where to write the proof data.",
@@ -96,6 +124,15 @@ const (
 	GramineSGXType = "gramine"
 )
 
+const (
+	JSONWitnessFormat        = "json"
+	GaikoBinaryWitnessFormat = "gaiko-binary"
+)
+
+// DefaultMaxWitnessSize caps the decompressed witness, it leaves room for a
+// batch of 768 blocks with full blobs.
+const DefaultMaxWitnessSize = 4 << 30
+
 var GlobalFlags = []cli.Flag{
 	GlobalSecretDirFlag,
 	GlobalConfigDirFlag,
@@ -110,11 +147,18 @@ type Arguments struct {
 	SGXType   string
 	ProofType witness.ProofType
 	// if SGXType is "debug", specify the SGX instance address with custom private key
-	SGXInstance     common.Address
-	SGXInstanceID   uint32
-	WitnessReader   io.Reader
-	ProofWriter     io.Writer
-	BootstrapWriter io.Writer
+	SGXInstance    common.Address
+	SGXInstanceID  uint32
+	WitnessReader  io.Reader
+	WitnessFormat  string
+	MaxWitnessSize int64
+	StrictWitness  bool
+	// WitnessEncrypted is set if WitnessReader yields the ECIES ciphertext of
+	// the witness.
+	WitnessEncrypted bool
+	Parallelism      int
+	ProofWriter      io.Writer
+	BootstrapWriter  io.Writer
 }
 
 func (args *Arguments) Copy() *Arguments {
@@ -133,6 +177,7 @@ func NewArguments(cli *cli.Context) *Arguments {
 		proofStr        = cli.String(ProofFlag.Name)
 		bootstrapStr    = cli.String(BootstrapFlag.Name)
 		bootstrapWriter io.Writer
+		maxWitnessSize  = cli.Int64(MaxWitnessSizeFlag.Name)
 	)
 	if witnessStr == stdinSelector || witnessStr == "" {
 		witnessReader = os.Stdin
@@ -141,6 +186,10 @@ func NewArguments(cli *cli.Context) *Arguments {
 			panic(err)
 		}
 	}
+	if maxWitnessSize <= 0 {
+		maxWitnessSize = DefaultMaxWitnessSize
+	}
+	witnessReader = compress.NewReader(witnessReader, maxWitnessSize)
 
 	if proofStr == stdoutSelector || proofStr == "" {
 		proofWriter = os.Stdout
@@ -157,14 +206,19 @@ func NewArguments(cli *cli.Context) *Arguments {
 		}
 	}
 	return &Arguments{
-		SecretDir:       secretDir,
-		ConfigDir:       configDir,
-		SGXType:         cli.String(GlobalSGXTypeFlag.Name),
-		ProofType:       witness.SGXGethProofType,
-		SGXInstanceID:   uint32(cli.Uint64(SGXInstanceIDFlag.Name)),
-		WitnessReader:   witnessReader,
-		ProofWriter:     proofWriter,
-		BootstrapWriter: bootstrapWriter,
+		SecretDir:        secretDir,
+		ConfigDir:        configDir,
+		SGXType:          cli.String(GlobalSGXTypeFlag.Name),
+		ProofType:        witness.SGXGethProofType,
+		SGXInstanceID:    uint32(cli.Uint64(SGXInstanceIDFlag.Name)),
+		WitnessReader:    witnessReader,
+		WitnessFormat:    cli.String(WitnessFormatFlag.Name),
+		MaxWitnessSize:   maxWitnessSize,
+		StrictWitness:    cli.Bool(StrictWitnessFlag.Name),
+		WitnessEncrypted: cli.Bool(WitnessEncryptedFlag.Name),
+		Parallelism:      cli.Int(ParallelismFlag.Name),
+		ProofWriter:      proofWriter,
+		BootstrapWriter:  bootstrapWriter,
 	}
 }
 
@@ -0,0 +1,65 @@
+package prover
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+	"github.com/taikoxyz/gaiko/internal/flags"
+	"github.com/taikoxyz/gaiko/pkg/compress"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+)
+
+// witnessKeyInfo is mixed with the sealed key to derive the witness key, and
+// shared with the KDF of the encryption.
+var witnessKeyInfo = []byte("gaiko witness encryption")
+
+// WitnessKey derives the key the witnesses are encrypted to from the sealed
+// private key, so it's only known inside the enclave and doesn't need sealing
+// of its own.
+func WitnessKey(privKey *ecdsa.PrivateKey) (*ecdsa.PrivateKey, error) {
+	seed := append(bytes.Clone(witnessKeyInfo), crypto.FromECDSA(privKey)...)
+	return crypto.ToECDSA(keccak.Keccak(seed).Bytes())
+}
+
+// EncryptWitness encrypts a witness to the witness key of an instance with
+// ECIES over secp256k1. Compression must happen before, the ciphertext doesn't
+// compress.
+//
+// The encryption keeps the witness from the host, it doesn't authenticate the
+// sender: anyone with the public witness key can encrypt a witness. That's no
+// weaker than a witness sent in the clear, every witness is verified before
+// its proof is signed.
+func EncryptWitness(witnessKey *ecdsa.PublicKey, data []byte) ([]byte, error) {
+	return ecies.Encrypt(rand.Reader, ecies.ImportECDSAPublic(witnessKey), data, witnessKeyInfo, nil)
+}
+
+// decryptWitness replaces the witness reader of args by the decrypted witness.
+// The ciphertext is held by the size limit the witness reader of args already
+// applies, like a witness sent in the clear. The plaintext may be compressed,
+// its decompression is held by the same limit.
+//
+// ECIES checks a single MAC over the whole ciphertext before it decrypts, so
+// the ciphertext is read in full and the plaintext is a second copy of the
+// same size, the ciphertext is released once it's decrypted.
+func decryptWitness(args *flags.Arguments, privKey *ecdsa.PrivateKey) (*flags.Arguments, error) {
+	witnessKey, err := WitnessKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(args.WitnessReader)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := ecies.ImportECDSA(witnessKey).Decrypt(data, witnessKeyInfo, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt witness: %w", err)
+	}
+	args = args.Copy()
+	args.WitnessReader = compress.NewReader(bytes.NewReader(plain), args.MaxWitnessSize)
+	return args, nil
+}
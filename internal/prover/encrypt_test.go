@@ -0,0 +1,58 @@
+package prover
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/gaiko/internal/flags"
+	"github.com/taikoxyz/gaiko/pkg/compress"
+)
+
+func TestDecryptWitness(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	witnessKey, err := WitnessKey(privKey)
+	require.NoError(t, err)
+
+	data := []byte(`{"inputs":[]}`)
+	enc, err := EncryptWitness(&witnessKey.PublicKey, data)
+	require.NoError(t, err)
+
+	args := &flags.Arguments{
+		WitnessReader:  bytes.NewReader(enc),
+		MaxWitnessSize: flags.DefaultMaxWitnessSize,
+	}
+	decArgs, err := decryptWitness(args, privKey)
+	require.NoError(t, err)
+	got, err := io.ReadAll(decArgs.WitnessReader)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	// the ciphertext is held by the limit of the witness reader, like a
+	// witness sent in the clear
+	args.WitnessReader = compress.NewReader(bytes.NewReader(enc), int64(len(enc)))
+	_, err = decryptWitness(args, privKey)
+	require.NoError(t, err)
+	args.WitnessReader = compress.NewReader(bytes.NewReader(enc), int64(len(enc)-1))
+	_, err = decryptWitness(args, privKey)
+	assert.ErrorIs(t, err, compress.ErrTooLarge)
+
+	// a tampered witness fails the MAC
+	enc[len(enc)-1] ^= 1
+	args.WitnessReader = bytes.NewReader(enc)
+	_, err = decryptWitness(args, privKey)
+	assert.Error(t, err)
+
+	// and so does a witness encrypted to another instance
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	enc, err = EncryptWitness(&other.PublicKey, data)
+	require.NoError(t, err)
+	args.WitnessReader = bytes.NewReader(enc)
+	_, err = decryptWitness(args, privKey)
+	assert.Error(t, err)
+}
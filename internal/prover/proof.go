@@ -1,7 +1,11 @@
 package prover
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"crypto/ecdsa"
+	"encoding"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
@@ -109,7 +113,7 @@ func genAggregateProof(
 	}
 
 	proof := NewAggregateProof(args.SGXInstanceID, oldInstance, newInstance, sign)
-	quote, err := provider.LoadQuote(args, newInstance)
+	quote, err := loadQuote(args, provider, prevPrivKey, newInstance)
 	if err != nil {
 		return err
 	}
@@ -129,15 +133,6 @@ func genOneshotProof(
 	input witness.WitnessInput,
 	provider tee.Provider,
 ) error {
-	err := json.NewDecoder(args.WitnessReader).Decode(input)
-	if err != nil {
-		return err
-	}
-	log.Info("Start generate proof: ", "id", input.ID())
-	err = transition.ExecuteAndVerify(ctx, args, input)
-	if err != nil {
-		return err
-	}
 	prevPrivKey, err := provider.LoadPrivateKey(args)
 	if err != nil {
 		return err
@@ -147,7 +142,12 @@ func genOneshotProof(
 	if args.SGXType == "debug" {
 		newInstance = args.SGXInstance
 	}
-	pi, err := witness.NewPublicInput(input, args.ProofType, args.SGXType, newInstance)
+	if args.WitnessEncrypted {
+		if args, err = decryptWitness(args, prevPrivKey); err != nil {
+			return err
+		}
+	}
+	pi, err := verifyWitness(ctx, args, input, newInstance)
 	if err != nil {
 		return err
 	}
@@ -162,7 +162,7 @@ func genOneshotProof(
 	}
 
 	proof := NewOneshotProof(args.SGXInstanceID, newInstance, sign)
-	quote, err := provider.LoadQuote(args, newInstance)
+	quote, err := loadQuote(args, provider, prevPrivKey, newInstance)
 	if err != nil {
 		return err
 	}
@@ -175,3 +175,98 @@ func genOneshotProof(
 		Input:           piHash,
 	}).Output(args.ProofWriter)
 }
+
+// loadQuote loads the quote of newInstance, binding the witness key derived
+// from privKey if the witness was encrypted to it.
+func loadQuote(
+	args *flags.Arguments,
+	provider tee.Provider,
+	privKey *ecdsa.PrivateKey,
+	newInstance common.Address,
+) (tee.Quote, error) {
+	if !args.WitnessEncrypted {
+		return provider.LoadQuote(args, tee.NewReportData(newInstance, nil))
+	}
+	witnessKey, err := WitnessKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	return provider.LoadQuote(args, tee.NewReportData(newInstance, &witnessKey.PublicKey))
+}
+
+// verifyWitness decodes the witness, executes its blocks and builds its public
+// input. The blocks of a JSON batch start executing while the rest of the batch
+// is decoded, and the checks of the whole witness run alongside the last
+// executions.
+func verifyWitness(
+	ctx context.Context,
+	args *flags.Arguments,
+	input witness.WitnessInput,
+	newInstance common.Address,
+) (*witness.PublicInput, error) {
+	isJSON := args.WitnessFormat == flags.JSONWitnessFormat || args.WitnessFormat == ""
+	if args.StrictWitness && isJSON {
+		// the whole witness is needed to list all its violations at once, strict
+		// mode gives up streaming the decode
+		data, err := io.ReadAll(args.WitnessReader)
+		if err != nil {
+			return nil, err
+		}
+		if err := witness.Validate(data, input); err != nil {
+			return nil, err
+		}
+		args = args.Copy()
+		args.WitnessReader = bytes.NewReader(data)
+	}
+	p := transition.NewPipeline(ctx, args.Parallelism)
+	batch, streamed := input.(*witness.BatchGuestInput)
+	streamed = streamed && isJSON
+	if streamed {
+		if err := batch.DecodeStreamFunc(args.WitnessReader, p.Execute); err != nil {
+			return nil, cmp.Or(p.Wait(), err)
+		}
+	} else if err := decodeWitness(args, input); err != nil {
+		return nil, err
+	}
+	log.Info("Start generate proof: ", "id", input.ID())
+	if !streamed {
+		for pair := range input.GuestInputs() {
+			if err := p.Execute(pair.Input); err != nil {
+				break
+			}
+		}
+	}
+	var pi *witness.PublicInput
+	_ = p.Go(func(context.Context) error {
+		var err error
+		pi, err = witness.NewPublicInput(input, args.ProofType, args.SGXType, newInstance)
+		return err
+	})
+	if err := p.Wait(); err != nil {
+		return nil, err
+	}
+	return pi, nil
+}
+
+// decodeWitness decodes the witness of args in the format it was sent in.
+func decodeWitness(args *flags.Arguments, input witness.WitnessInput) error {
+	switch args.WitnessFormat {
+	case flags.GaikoBinaryWitnessFormat:
+		dec, ok := input.(encoding.BinaryUnmarshaler)
+		if !ok {
+			return fmt.Errorf("binary witness not supported for %T", input)
+		}
+		data, err := io.ReadAll(args.WitnessReader)
+		if err != nil {
+			return err
+		}
+		return dec.UnmarshalBinary(data)
+	case flags.JSONWitnessFormat, "":
+		if dec, ok := input.(witness.StreamDecoder); ok {
+			return dec.DecodeStream(args.WitnessReader)
+		}
+		return json.NewDecoder(args.WitnessReader).Decode(input)
+	default:
+		return fmt.Errorf("unknown witness format: %s", args.WitnessFormat)
+	}
+}
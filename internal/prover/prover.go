@@ -9,7 +9,9 @@ import (
 type Prover interface {
 	Oneshot(ctx context.Context, args *flags.Arguments) error
 	BatchOneshot(ctx context.Context, args *flags.Arguments) error
+	L1BlockOneshot(ctx context.Context, args *flags.Arguments) error
 	Aggregate(ctx context.Context, args *flags.Arguments) error
 	Bootstrap(ctx context.Context, args *flags.Arguments) error
 	Check(ctx context.Context, args *flags.Arguments) error
+	WitnessKey(ctx context.Context, args *flags.Arguments) error
 }
@@ -2,6 +2,7 @@ package prover
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -35,6 +36,11 @@ func (p *SGXProver) BatchOneshot(ctx context.Context, args *flags.Arguments) err
 	return genOneshotProof(ctx, args, &input, p.sgxProvider)
 }
 
+func (p *SGXProver) L1BlockOneshot(ctx context.Context, args *flags.Arguments) error {
+	var input witness.L1BlockGuestInput
+	return genOneshotProof(ctx, args, &input, p.sgxProvider)
+}
+
 func (p *SGXProver) Aggregate(ctx context.Context, args *flags.Arguments) error {
 	return genAggregateProof(ctx, args, p.sgxProvider)
 }
@@ -52,13 +58,19 @@ func (p *SGXProver) Bootstrap(ctx context.Context, args *flags.Arguments) error
 	newInstance := crypto.PubkeyToAddress(privKey.PublicKey)
 	fmt.Printf("Instance address: %#x\n", newInstance)
 
-	quote, err := p.sgxProvider.LoadQuote(args, newInstance)
+	witnessKey, err := WitnessKey(privKey)
+	if err != nil {
+		return err
+	}
+	// the registered quote keeps the plain layout, witness-key binds the key
+	quote, err := p.sgxProvider.LoadQuote(args, tee.NewReportData(newInstance, nil))
 	if err != nil {
 		return err
 	}
 	b := &tee.BootstrapData{
 		PublicKey:   crypto.FromECDSAPub(&privKey.PublicKey),
 		NewInstance: newInstance,
+		WitnessKey:  crypto.FromECDSAPub(&witnessKey.PublicKey),
 		Quote:       quote.Bytes(),
 	}
 
@@ -69,3 +81,27 @@ func (p *SGXProver) Check(ctx context.Context, args *flags.Arguments) error {
 	_, err := p.sgxProvider.LoadPrivateKey(args)
 	return err
 }
+
+// WitnessKey writes the key to encrypt the witnesses to, along with a quote
+// binding it to the instance.
+func (p *SGXProver) WitnessKey(ctx context.Context, args *flags.Arguments) error {
+	privKey, err := p.sgxProvider.LoadPrivateKey(args)
+	if err != nil {
+		return err
+	}
+	witnessKey, err := WitnessKey(privKey)
+	if err != nil {
+		return err
+	}
+	newInstance := crypto.PubkeyToAddress(privKey.PublicKey)
+	quote, err := p.sgxProvider.LoadQuote(args, tee.NewReportData(newInstance, &witnessKey.PublicKey))
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(args.ProofWriter).Encode(&tee.BootstrapData{
+		PublicKey:   crypto.FromECDSAPub(&privKey.PublicKey),
+		NewInstance: newInstance,
+		WitnessKey:  crypto.FromECDSAPub(&witnessKey.PublicKey),
+		Quote:       quote.Bytes(),
+	})
+}
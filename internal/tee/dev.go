@@ -6,7 +6,6 @@ import (
 	"crypto/ecdsa"
 	"encoding/hex"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/taikoxyz/gaiko/internal/flags"
 )
@@ -96,7 +95,7 @@ func NewTDXProvider(_ *flags.Arguments) Provider {
 	}
 }
 
-func (p *DevProvider) LoadQuote(args *flags.Arguments, key common.Address) (Quote, error) {
+func (p *DevProvider) LoadQuote(args *flags.Arguments, data ReportData) (Quote, error) {
 	return QuoteV3(devQuoteV3), nil
 }
 
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -14,7 +13,7 @@ import (
 
 func TestDevProvider(t *testing.T) {
 	p := NewSGXProvider(nil)
-	q, err := p.LoadQuote(nil, common.Address{})
+	q, err := p.LoadQuote(nil, ReportData{})
 	require.NoError(t, err)
 	assert.Equal(t, devQuoteV3, q.Bytes())
 	privKey, err := p.LoadPrivateKey(nil)
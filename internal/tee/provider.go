@@ -19,8 +19,8 @@ const (
 
 // Provider is the interface that wraps the basic methods to interact with the TEE.
 type Provider interface {
-	// LoadQuote loads the quote from the TEE.
-	LoadQuote(args *flags.Arguments, key common.Address) (Quote, error)
+	// LoadQuote loads the quote of data from the TEE.
+	LoadQuote(args *flags.Arguments, data ReportData) (Quote, error)
 	// LoadPrivateKey loads the encrypted(mrenclave related) private key from the TEE.
 	// The encrypted data only can be decrypted by the same instance(image).
 	LoadPrivateKey(args *flags.Arguments) (*ecdsa.PrivateKey, error)
@@ -34,6 +34,7 @@ type Provider interface {
 type BootstrapData struct {
 	PublicKey   hexutil.Bytes  `json:"public_key"`
 	NewInstance common.Address `json:"new_instance"`
+	WitnessKey  hexutil.Bytes  `json:"witness_key"`
 	Quote       hexutil.Bytes  `json:"quote"`
 }
 
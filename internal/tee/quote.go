@@ -1,9 +1,35 @@
 package tee
 
 import (
+	"crypto/ecdsa"
 	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
 )
 
+// ReportData is the user data bound into a quote. It starts with the instance
+// address, which the SGX verifier registers from its first 20 bytes, and ends
+// with the hash of the key the witnesses may be encrypted to. Without a
+// witness key it's the address followed by zeros.
+type ReportData [64]byte
+
+// NewReportData creates the report data of instance, witnessKey may be nil.
+func NewReportData(instance common.Address, witnessKey *ecdsa.PublicKey) ReportData {
+	var data ReportData
+	copy(data[:common.AddressLength], instance.Bytes())
+	if witnessKey != nil {
+		copy(data[32:], keccak.Keccak(crypto.FromECDSAPub(witnessKey)).Bytes())
+	}
+	return data
+}
+
+// hasWitnessKey reports whether a witness key is bound.
+func (d *ReportData) hasWitnessKey() bool {
+	return [32]byte(d[32:]) != [32]byte{}
+}
+
 // Quote is the interface that wraps the basic methods to interact with the quote.
 type Quote interface {
 	// Bytes returns the origin data of the quote.
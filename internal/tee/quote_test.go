@@ -0,0 +1,30 @@
+package tee
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+)
+
+func TestReportData(t *testing.T) {
+	instance := common.HexToAddress("0x1670130000000000000000000000000000000001")
+
+	// the verifier reads the instance from the first 20 bytes
+	data := NewReportData(instance, nil)
+	var want ReportData
+	copy(want[:], instance.Bytes())
+	assert.Equal(t, want, data)
+	assert.False(t, data.hasWitnessKey())
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	data = NewReportData(instance, &key.PublicKey)
+	assert.Equal(t, instance, common.BytesToAddress(data[:common.AddressLength]))
+	assert.Equal(t, make([]byte, 32-common.AddressLength), data[common.AddressLength:32])
+	assert.Equal(t, keccak.Keccak(crypto.FromECDSAPub(&key.PublicKey)).Bytes(), data[32:])
+	assert.True(t, data.hasWitnessKey())
+}
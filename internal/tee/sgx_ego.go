@@ -23,8 +23,13 @@ func NewEgoProvider() *SGXEgoProvider {
 	return &SGXEgoProvider{}
 }
 
-func (p *SGXEgoProvider) LoadQuote(args *flags.Arguments, key common.Address) (Quote, error) {
-	q, err := getRemoteReport(key.Bytes())
+func (p *SGXEgoProvider) LoadQuote(args *flags.Arguments, data ReportData) (Quote, error) {
+	userReport := data[:]
+	if !data.hasWitnessKey() {
+		// the instance address alone, as before witness keys
+		userReport = data[:common.AddressLength]
+	}
+	q, err := getRemoteReport(userReport)
 	if err != nil {
 		return nil, err
 	}
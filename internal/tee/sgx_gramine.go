@@ -7,7 +7,6 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/taikoxyz/gaiko/internal/flags"
 )
@@ -27,8 +26,8 @@ func NewGramineProvider() *SGXGramineProvider {
 	return &SGXGramineProvider{}
 }
 
-func (p *SGXGramineProvider) LoadQuote(args *flags.Arguments, key common.Address) (Quote, error) {
-	q, err := getQuote(key)
+func (p *SGXGramineProvider) LoadQuote(args *flags.Arguments, data ReportData) (Quote, error) {
+	q, err := getQuote(data)
 	if err != nil {
 		return nil, err
 	}
@@ -50,22 +49,20 @@ func (p *SGXGramineProvider) SaveBootstrap(args *flags.Arguments, b *BootstrapDa
 	return b.SaveToFile(args)
 }
 
-func saveAttestationUserReportData(pubKey common.Address) error {
-	extendedPubkey := make([]byte, 64)
-	copy(extendedPubkey, pubKey.Bytes())
+func saveAttestationUserReportData(data ReportData) error {
 	userReportDataFile, err := os.OpenFile(attestationUserReportDataDeviceFile, os.O_WRONLY, 0666)
 	if err != nil {
 		return err
 	}
 	defer userReportDataFile.Close()
-	if _, err := userReportDataFile.Write(extendedPubkey); err != nil {
+	if _, err := userReportDataFile.Write(data[:]); err != nil {
 		return err
 	}
 	return nil
 }
 
-func getQuote(pubkey common.Address) ([]byte, error) {
-	err := saveAttestationUserReportData(pubkey)
+func getQuote(data ReportData) ([]byte, error) {
+	err := saveAttestationUserReportData(data)
 	if err != nil {
 		return nil, err
 	}
@@ -5,7 +5,6 @@ package tee
 import (
 	"crypto/ecdsa"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/go-tdx-guest/client"
 	labi "github.com/google/go-tdx-guest/client/linuxabi"
 	"github.com/taikoxyz/gaiko/internal/flags"
@@ -20,16 +19,13 @@ func NewTDXProvider() Provider {
 	return &TDXProvider{}
 }
 
-func (p *TDXProvider) LoadQuote(args *flags.Arguments, key common.Address) (Quote, error) {
+func (p *TDXProvider) LoadQuote(args *flags.Arguments, data ReportData) (Quote, error) {
 	tdxQuoteProvider, err := client.GetQuoteProvider()
 	if err != nil {
 		return nil, err
 	}
 
-	var reportData64 [labi.TdReportDataSize]byte
-	copy(reportData64[:], key.Bytes())
-
-	q, err := client.GetRawQuote(tdxQuoteProvider, reportData64)
+	q, err := client.GetRawQuote(tdxQuoteProvider, [labi.TdReportDataSize]byte(data))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,284 @@
+package transition
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+)
+
+// Slot is a storage slot of an account.
+type Slot struct {
+	Address common.Address `json:"address"`
+	Slot    common.Hash    `json:"slot"`
+}
+
+// Report lists what the witness of a block lacked and what it carried without
+// being used by the execution.
+type Report struct {
+	Block           uint64           `json:"block"`
+	MissingAccounts []common.Address `json:"missing_accounts"`
+	MissingSlots    []Slot           `json:"missing_slots"`
+	MissingCodes    []common.Hash    `json:"missing_codes"`
+	MissingHeaders  []uint64         `json:"missing_headers"`
+	UnusedNodes     []common.Hash    `json:"unused_nodes"`
+	UnusedContracts []common.Hash    `json:"unused_contracts"`
+	UnusedHeaders   []uint64         `json:"unused_headers"`
+	// Err is the error the execution ended with, a witness that isn't
+	// complete usually fails with a missing trie node.
+	Err error `json:"-"`
+}
+
+// Complete reports whether the execution found everything it needed.
+func (r *Report) Complete() bool {
+	return len(r.MissingAccounts) == 0 && len(r.MissingSlots) == 0 &&
+		len(r.MissingCodes) == 0 && len(r.MissingHeaders) == 0
+}
+
+// Minimal reports whether everything the witness supplied was used.
+func (r *Report) Minimal() bool {
+	return len(r.UnusedNodes) == 0 && len(r.UnusedContracts) == 0 && len(r.UnusedHeaders) == 0
+}
+
+// Check executes every block of the witness on a state that records its reads
+// and reports the missing and the unused parts of the witness. Unlike
+// ExecuteAndVerify it doesn't stop at the first missing node.
+func Check(input witness.WitnessInput) ([]*Report, error) {
+	chainConfig, err := input.ChainConfig()
+	if err != nil {
+		return nil, err
+	}
+	var reports []*Report
+	for pair := range input.GuestInputs() {
+		report, err := checkWitness(pair, chainConfig)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func checkWitness(pair *witness.Pair, chainConfig *params.ChainConfig) (*Report, error) {
+	g := pair.Input
+	wit, err := g.NewWitness()
+	if err != nil {
+		return nil, err
+	}
+	db := &recordingDB{Database: rawdb.NewMemoryDatabase(), reads: map[common.Hash]struct{}{}}
+	nodes := make(map[common.Hash]struct{}, len(wit.State))
+	for node := range wit.State {
+		hash := keccak.Keccak([]byte(node))
+		nodes[hash] = struct{}{}
+		rawdb.WriteLegacyTrieNode(db.Database, hash, []byte(node))
+	}
+	for code := range wit.Codes {
+		rawdb.WriteCode(db.Database, keccak.Keccak([]byte(code)), []byte(code))
+	}
+	sdb := &recordingStateDB{
+		Database: state.NewDatabase(triedb.NewDatabase(db, triedb.HashDefaults), nil),
+		rec:      newRecorder(),
+	}
+	headers := newHeaderRecorder(g)
+
+	report := &Report{Block: g.Block.NumberU64()}
+	stateDB, err := state.New(g.ParentHeader.Root, sdb)
+	if err == nil {
+		stateDB, err = apply(vm.Config{}, stateDB, g.Block, g.Block.Transactions(), headers.getHash, chainConfig)
+	}
+	if err == nil {
+		err = stateDB.Error()
+	}
+	if err == nil {
+		if root := stateDB.IntermediateRoot(chainConfig.IsEIP158(g.Block.Number())); root != g.Block.Root() {
+			err = fmt.Errorf("block %d state root mismatch: expected %#x, got %#x",
+				g.Block.NumberU64(), g.Block.Root(), root)
+		}
+	}
+	report.Err = err
+
+	rec := sdb.rec
+	report.MissingAccounts = sortedKeys(rec.missingAccounts, func(a, b common.Address) int { return a.Cmp(b) })
+	report.MissingSlots = sortedKeys(rec.missingSlots, func(a, b Slot) int {
+		return cmp.Or(a.Address.Cmp(b.Address), a.Slot.Cmp(b.Slot))
+	})
+	report.MissingCodes = sortedKeys(rec.missingCodes, common.Hash.Cmp)
+	report.MissingHeaders = sortedKeys(headers.missing, cmp.Compare[uint64])
+	for hash := range nodes {
+		if _, ok := db.reads[hash]; !ok {
+			report.UnusedNodes = append(report.UnusedNodes, hash)
+		}
+	}
+	slices.SortFunc(report.UnusedNodes, common.Hash.Cmp)
+	for _, code := range g.Contracts {
+		hash := keccak.Keccak(code)
+		if _, ok := rec.codes[hash]; !ok && !slices.Contains(report.UnusedContracts, hash) {
+			report.UnusedContracts = append(report.UnusedContracts, hash)
+		}
+	}
+	slices.SortFunc(report.UnusedContracts, common.Hash.Cmp)
+	for _, header := range g.AncestorHeaders {
+		if _, ok := headers.used[header.Number.Uint64()]; !ok {
+			report.UnusedHeaders = append(report.UnusedHeaders, header.Number.Uint64())
+		}
+	}
+	slices.Sort(report.UnusedHeaders)
+	return report, nil
+}
+
+// recordingDB records the trie nodes read from the database by their hash.
+type recordingDB struct {
+	ethdb.Database
+	mu    sync.Mutex
+	reads map[common.Hash]struct{}
+}
+
+func (db *recordingDB) record(key []byte) {
+	if len(key) != common.HashLength {
+		return
+	}
+	db.mu.Lock()
+	db.reads[common.BytesToHash(key)] = struct{}{}
+	db.mu.Unlock()
+}
+
+func (db *recordingDB) Get(key []byte) ([]byte, error) {
+	db.record(key)
+	return db.Database.Get(key)
+}
+
+func (db *recordingDB) Has(key []byte) (bool, error) {
+	db.record(key)
+	return db.Database.Has(key)
+}
+
+// recorder collects the state a StateDB asked for.
+type recorder struct {
+	mu              sync.Mutex
+	codes           map[common.Hash]struct{}
+	missingAccounts map[common.Address]struct{}
+	missingSlots    map[Slot]struct{}
+	missingCodes    map[common.Hash]struct{}
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		codes:           map[common.Hash]struct{}{},
+		missingAccounts: map[common.Address]struct{}{},
+		missingSlots:    map[Slot]struct{}{},
+		missingCodes:    map[common.Hash]struct{}{},
+	}
+}
+
+// recordingStateDB hands out readers that report to rec.
+type recordingStateDB struct {
+	state.Database
+	rec *recorder
+}
+
+func (db *recordingStateDB) Reader(root common.Hash) (state.Reader, error) {
+	reader, err := db.Database.Reader(root)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingReader{Reader: reader, rec: db.rec}, nil
+}
+
+type recordingReader struct {
+	state.Reader
+	rec *recorder
+}
+
+func (r *recordingReader) Account(addr common.Address) (*types.StateAccount, error) {
+	acc, err := r.Reader.Account(addr)
+	if err != nil {
+		r.rec.mu.Lock()
+		r.rec.missingAccounts[addr] = struct{}{}
+		r.rec.mu.Unlock()
+	}
+	return acc, err
+}
+
+func (r *recordingReader) Storage(addr common.Address, slot common.Hash) (common.Hash, error) {
+	value, err := r.Reader.Storage(addr, slot)
+	if err != nil {
+		r.rec.mu.Lock()
+		r.rec.missingSlots[Slot{addr, slot}] = struct{}{}
+		r.rec.mu.Unlock()
+	}
+	return value, err
+}
+
+func (r *recordingReader) Code(addr common.Address, codeHash common.Hash) ([]byte, error) {
+	code, err := r.Reader.Code(addr, codeHash)
+	r.recordCode(codeHash, err != nil || len(code) == 0)
+	return code, err
+}
+
+func (r *recordingReader) CodeSize(addr common.Address, codeHash common.Hash) (int, error) {
+	size, err := r.Reader.CodeSize(addr, codeHash)
+	r.recordCode(codeHash, err != nil || size == 0)
+	return size, err
+}
+
+func (r *recordingReader) recordCode(codeHash common.Hash, missing bool) {
+	if codeHash == types.EmptyCodeHash {
+		return
+	}
+	r.rec.mu.Lock()
+	defer r.rec.mu.Unlock()
+	if missing {
+		r.rec.missingCodes[codeHash] = struct{}{}
+	} else {
+		r.rec.codes[codeHash] = struct{}{}
+	}
+}
+
+// headerRecorder serves the block hashes of the ancestor headers and records
+// which numbers were asked for.
+type headerRecorder struct {
+	mu      sync.Mutex
+	hashes  map[uint64]common.Hash
+	used    map[uint64]struct{}
+	missing map[uint64]struct{}
+}
+
+func newHeaderRecorder(g *witness.GuestInput) *headerRecorder {
+	h := &headerRecorder{
+		hashes:  map[uint64]common.Hash{g.ParentHeader.Number.Uint64(): g.ParentHeader.Hash()},
+		used:    map[uint64]struct{}{},
+		missing: map[uint64]struct{}{},
+	}
+	for _, header := range g.AncestorHeaders {
+		h.hashes[header.Number.Uint64()] = header.Hash()
+	}
+	return h
+}
+
+func (h *headerRecorder) getHash(num uint64) common.Hash {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hash, ok := h.hashes[num]
+	if ok {
+		h.used[num] = struct{}{}
+	} else {
+		h.missing[num] = struct{}{}
+	}
+	return hash
+}
+
+func sortedKeys[K comparable](m map[K]struct{}, compare func(a, b K) int) []K {
+	return slices.SortedFunc(maps.Keys(m), compare)
+}
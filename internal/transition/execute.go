@@ -18,12 +18,12 @@ import (
 	"github.com/taikoxyz/gaiko/internal/flags"
 	"github.com/taikoxyz/gaiko/internal/witness"
 	"github.com/taikoxyz/gaiko/pkg/keccak"
-	"golang.org/x/sync/errgroup"
+	"github.com/taikoxyz/gaiko/pkg/mpt"
 )
 
 // ExecuteAndVerify executes and verifies the given arguments using the provided witness.
 // It retrieves the chain configuration from the witness and processes each guest input
-// concurrently using an error group.
+// concurrently on a Pipeline.
 func ExecuteAndVerify(
 	ctx context.Context,
 	args *flags.Arguments,
@@ -33,24 +33,27 @@ func ExecuteAndVerify(
 	if err != nil {
 		return err
 	}
-	eg, ctx := errgroup.WithContext(ctx)
+	p := NewPipeline(ctx, args.Parallelism)
 	for pair := range input.GuestInputs() {
-		pair := pair // https://go.dev/doc/faq#closures_and_goroutines
-		eg.Go(func() error {
-			return executeWitness(ctx, pair, chainConfig)
+		err := p.Go(func(ctx context.Context) error {
+			return executeWitness(ctx, pair, chainConfig, p.sem)
 		})
+		if err != nil {
+			break
+		}
 	}
-	return eg.Wait()
+	return p.Wait()
 }
 
 func executeWitness(
 	_ context.Context,
 	pair *witness.Pair,
 	chainConfig *params.ChainConfig,
+	sem mpt.Semaphore,
 ) error {
 	g := pair.Input
 	// txs := pair.Txs
-	wit, err := g.NewWitness()
+	wit, err := g.NewWitnessWithin(sem)
 	if err != nil {
 		return err
 	}
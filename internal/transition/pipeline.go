@@ -0,0 +1,63 @@
+package transition
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/pkg/mpt"
+	"golang.org/x/sync/errgroup"
+)
+
+// Pipeline runs the executions of the blocks of a witness and the checks of
+// the whole witness as they become ready, at most limit goroutines at a time
+// counting the ones that hash the tries of the blocks. The first error cancels
+// the tasks that haven't started yet.
+type Pipeline struct {
+	eg  *errgroup.Group
+	ctx context.Context
+	sem mpt.Semaphore
+}
+
+// NewPipeline creates a Pipeline, a limit below 1 means GOMAXPROCS.
+func NewPipeline(ctx context.Context, limit int) *Pipeline {
+	if limit < 1 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	eg, ctx := errgroup.WithContext(ctx)
+	return &Pipeline{eg: eg, ctx: ctx, sem: mpt.NewSemaphore(limit)}
+}
+
+// Go schedules fn, it waits for a free slot. It fails once the pipeline is
+// canceled, Wait returns the error that canceled it.
+func (p *Pipeline) Go(fn func(ctx context.Context) error) error {
+	if err := p.sem.Acquire(p.ctx); err != nil {
+		return err
+	}
+	p.eg.Go(func() error {
+		defer p.sem.Release()
+		if err := p.ctx.Err(); err != nil {
+			return err
+		}
+		return fn(p.ctx)
+	})
+	return nil
+}
+
+// Execute schedules the execution of a block and the check of its roots. It
+// only uses the fields of g itself, so it can run while the rest of a batch is
+// still being decoded.
+func (p *Pipeline) Execute(g *witness.GuestInput) error {
+	return p.Go(func(ctx context.Context) error {
+		chainConfig, err := g.ChainConfig()
+		if err != nil {
+			return err
+		}
+		return executeWitness(ctx, &witness.Pair{Input: g, Txs: g.Block.Transactions()}, chainConfig, p.sem)
+	})
+}
+
+// Wait waits for the scheduled tasks and returns the first error.
+func (p *Pipeline) Wait() error {
+	return p.eg.Wait()
+}
@@ -20,6 +20,19 @@ func (a AccessList) GethType() types.AccessList {
 	return accessList
 }
 
+// NewAccessList converts a geth access list to the raiko format.
+func NewAccessList(a types.AccessList) AccessList {
+	accessList := make(AccessList, len(a))
+	for i, tuple := range a {
+		storageKeys := tuple.StorageKeys
+		if storageKeys == nil {
+			storageKeys = []common.Hash{}
+		}
+		accessList[i] = &AccessTuple{Address: tuple.Address, StorageKeys: storageKeys}
+	}
+	return accessList
+}
+
 type AccessTuple struct {
 	Address     common.Address `json:"address"      gencodec:"required"`
 	StorageKeys []common.Hash  `json:"storage_keys" gencodec:"required"`
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+)
+
+type AuthorizationList []*SignedAuthorization
+
+func (a AuthorizationList) GethType() []types.SetCodeAuthorization {
+	if a == nil {
+		log.Warn("missing AuthorizationList when converting to GethType")
+		return nil
+	}
+	authList := make([]types.SetCodeAuthorization, len(a))
+	for i, auth := range a {
+		authList[i] = *auth.GethType()
+	}
+	return authList
+}
+
+// NewAuthorizationList converts geth set-code authorizations to the raiko format.
+func NewAuthorizationList(a []types.SetCodeAuthorization) AuthorizationList {
+	authList := make(AuthorizationList, len(a))
+	for i, auth := range a {
+		authList[i] = &SignedAuthorization{
+			ChainID: auth.ChainID.ToBig(),
+			Address: auth.Address,
+			Nonce:   auth.Nonce,
+			YParity: auth.V,
+			R:       auth.R.ToBig(),
+			S:       auth.S.ToBig(),
+		}
+	}
+	return authList
+}
+
+//go:generate go run github.com/fjl/gencodec -type SignedAuthorization -field-override signedAuthorizationMarshaling -out gen_signed_authorization.go
+
+// SignedAuthorization represents an EIP-7702 authorization has the same format with raiko.
+type SignedAuthorization struct {
+	ChainID *big.Int       `json:"chain_id" gencodec:"required"`
+	Address common.Address `json:"address"  gencodec:"required"`
+	Nonce   uint64         `json:"nonce"    gencodec:"required"`
+	YParity uint8          `json:"y_parity" gencodec:"required"`
+	R       *big.Int       `json:"r"        gencodec:"required"`
+	S       *big.Int       `json:"s"        gencodec:"required"`
+}
+
+type signedAuthorizationMarshaling struct {
+	ChainID *math.HexOrDecimal256 `json:"chain_id" gencodec:"required"`
+	Nonce   Decimal64             `json:"nonce"    gencodec:"required"`
+	YParity Decimal64             `json:"y_parity" gencodec:"required"`
+	R       *math.HexOrDecimal256 `json:"r"        gencodec:"required"`
+	S       *math.HexOrDecimal256 `json:"s"        gencodec:"required"`
+}
+
+func (a *SignedAuthorization) GethType() *types.SetCodeAuthorization {
+	if a == nil {
+		log.Warn("missing SignedAuthorization when converting to GethType")
+		return nil
+	}
+	return &types.SetCodeAuthorization{
+		ChainID: *uint256.MustFromBig(a.ChainID),
+		Address: a.Address,
+		Nonce:   a.Nonce,
+		V:       a.YParity,
+		R:       *uint256.MustFromBig(a.R),
+		S:       *uint256.MustFromBig(a.S),
+	}
+}
@@ -3,7 +3,6 @@ package types
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
 )
@@ -55,6 +54,42 @@ func (b *BatchProposed) GethType() *pacaya.TaikoInboxClientBatchProposed {
 	}
 }
 
+// NewBatchProposed converts a BatchProposed event to the raiko format.
+func NewBatchProposed(b *pacaya.TaikoInboxClientBatchProposed) *BatchProposed {
+	blocks := make([]*BlockParams, len(b.Info.Blocks))
+	for i, block := range b.Info.Blocks {
+		params := BlockParams(block)
+		blocks[i] = &params
+	}
+	baseFeeConfig := LibSharedDataBaseFeeConfig(b.Info.BaseFeeConfig)
+	return &BatchProposed{
+		Info: &BatchInfo{
+			TxsHash:            b.Info.TxsHash,
+			Blocks:             blocks,
+			BlobHashes:         b.Info.BlobHashes,
+			ExtraData:          b.Info.ExtraData,
+			Coinbase:           b.Info.Coinbase,
+			ProposedIn:         b.Info.ProposedIn,
+			BlobByteOffset:     b.Info.BlobByteOffset,
+			BlobByteSize:       b.Info.BlobByteSize,
+			BlobCreatedIn:      b.Info.BlobCreatedIn,
+			GasLimit:           b.Info.GasLimit,
+			LastBlockID:        b.Info.LastBlockId,
+			LastBlockTimestamp: b.Info.LastBlockTimestamp,
+			AnchorBlockID:      b.Info.AnchorBlockId,
+			AnchorBlockHash:    b.Info.AnchorBlockHash,
+			BaseFeeConfig:      &baseFeeConfig,
+		},
+		Meta: &BatchMetadata{
+			InfoHash:   b.Meta.InfoHash,
+			Proposer:   b.Meta.Proposer,
+			BatchID:    b.Meta.BatchId,
+			ProposedAt: b.Meta.ProposedAt,
+		},
+		TxList: b.TxList,
+	}
+}
+
 type batchProposedMarshaling struct {
 	TxList hexutil.Bytes `json:"txList" gencodec:"required"`
 }
@@ -70,9 +105,9 @@ type BatchMetadata struct {
 }
 
 type batchMetadataMarshaling struct {
-	InfoHash   common.Hash         `json:"infoHash"   gencodec:"required"`
-	BatchID    math.HexOrDecimal64 `json:"batchId"    gencodec:"required"`
-	ProposedAt math.HexOrDecimal64 `json:"proposedAt" gencodec:"required"`
+	InfoHash   common.Hash `json:"infoHash"   gencodec:"required"`
+	BatchID    Decimal64   `json:"batchId"    gencodec:"required"`
+	ProposedAt Decimal64   `json:"proposedAt" gencodec:"required"`
 }
 
 //go:generate go run github.com/fjl/gencodec -type LibSharedDataBaseFeeConfig -field-override libSharedDataBaseFeeConfigMarshaling -out gen_lib_shared_data_base_fee_config.go
@@ -87,7 +122,7 @@ type LibSharedDataBaseFeeConfig struct {
 }
 
 type libSharedDataBaseFeeConfigMarshaling struct {
-	MinGasExcess math.HexOrDecimal64 `json:"minGasExcess" gencodec:"required"`
+	MinGasExcess Decimal64 `json:"minGasExcess" gencodec:"required"`
 }
 
 //go:generate go run github.com/fjl/gencodec -type BlockParams  -field-override  blockParamsMarshaling -out gen_block_params.go
@@ -125,10 +160,10 @@ type BatchInfo struct {
 }
 
 type batchInfoMarshaling struct {
-	BlobHashes         []common.Hash       `json:"blobHashes"         gencodec:"required"`
-	ProposedIn         math.HexOrDecimal64 `json:"proposedIn"         gencodec:"required"`
-	BlobCreatedIn      math.HexOrDecimal64 `json:"blobCreatedIn"      gencodec:"required"`
-	LastBlockID        math.HexOrDecimal64 `json:"lastBlockId"        gencodec:"required"`
-	LastBlockTimestamp math.HexOrDecimal64 `json:"lastBlockTimestamp" gencodec:"required"`
-	AnchorBlockID      math.HexOrDecimal64 `json:"anchorBlockId"      gencodec:"required"`
+	BlobHashes         []common.Hash `json:"blobHashes"         gencodec:"required"`
+	ProposedIn         Decimal64     `json:"proposedIn"         gencodec:"required"`
+	BlobCreatedIn      Decimal64     `json:"blobCreatedIn"      gencodec:"required"`
+	LastBlockID        Decimal64     `json:"lastBlockId"        gencodec:"required"`
+	LastBlockTimestamp Decimal64     `json:"lastBlockTimestamp" gencodec:"required"`
+	AnchorBlockID      Decimal64     `json:"anchorBlockId"      gencodec:"required"`
 }
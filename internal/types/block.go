@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -10,6 +11,9 @@ type Block struct {
 	Body        TransactionSignedList `json:"body"        gencodec:"required"`
 	Ommers      Headers               `json:"ommers"      gencodec:"required"`
 	Withdrawals types.Withdrawals     `json:"withdrawals"`
+	// Requests are the EIP-7685 requests raiko may carry, geth blocks
+	// don't, they are committed to by the requests hash of the header.
+	Requests []hexutil.Bytes `json:"requests"`
 }
 
 func (b *Block) GethType() *types.Block {
@@ -23,3 +27,20 @@ func (b *Block) GethType() *types.Block {
 		Withdrawals:  b.Withdrawals,
 	})
 }
+
+// NewBlock converts a geth block to the raiko format.
+func NewBlock(b *types.Block) (*Block, error) {
+	if b == nil {
+		return nil, nil
+	}
+	body, err := NewTransactionSignedList(b.Transactions())
+	if err != nil {
+		return nil, err
+	}
+	return &Block{
+		Header:      NewHeader(b.Header()),
+		Body:        body,
+		Ommers:      NewHeaders(b.Uncles()),
+		Withdrawals: b.Withdrawals(),
+	}, nil
+}
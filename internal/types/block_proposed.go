@@ -57,6 +57,40 @@ func (b *BlockProposed) GethType() *ontake.TaikoL1ClientBlockProposed {
 	}
 }
 
+// NewBlockProposed converts a BlockProposed event to the raiko format.
+func NewBlockProposed(b *ontake.TaikoL1ClientBlockProposed) *BlockProposed {
+	deposits := make([]*EthDeposit, len(b.DepositsProcessed))
+	for i, deposit := range b.DepositsProcessed {
+		deposits[i] = &EthDeposit{
+			Recipient: deposit.Recipient,
+			Amount:    deposit.Amount,
+			ID:        deposit.Id,
+		}
+	}
+	return &BlockProposed{
+		BlockID:        b.BlockId,
+		AssignedProver: b.AssignedProver,
+		LivenessBond:   b.LivenessBond,
+		Meta: &BlockMetadata{
+			L1Hash:         b.Meta.L1Hash,
+			Difficulty:     b.Meta.Difficulty,
+			BlobHash:       b.Meta.BlobHash,
+			ExtraData:      b.Meta.ExtraData,
+			DepositsHash:   b.Meta.DepositsHash,
+			Coinbase:       b.Meta.Coinbase,
+			ID:             b.Meta.Id,
+			GasLimit:       b.Meta.GasLimit,
+			Timestamp:      b.Meta.Timestamp,
+			L1Height:       b.Meta.L1Height,
+			MinTier:        b.Meta.MinTier,
+			BlobUsed:       b.Meta.BlobUsed,
+			ParentMetaHash: b.Meta.ParentMetaHash,
+			Sender:         b.Meta.Sender,
+		},
+		DepositsProcessed: deposits,
+	}
+}
+
 type blockProposedMarshaling struct {
 	BlockID      *math.HexOrDecimal256 `json:"blockId"      gencodec:"required"`
 	LivenessBond *math.HexOrDecimal256 `json:"livenessBond" gencodec:"required"`
@@ -83,9 +117,9 @@ type BlockMetadata struct {
 }
 
 type blockMetadataMarshaling struct {
-	ID        math.HexOrDecimal64 `json:"id"        gencodec:"required"`
-	Timestamp math.HexOrDecimal64 `json:"timestamp" gencodec:"required"`
-	L1Height  math.HexOrDecimal64 `json:"l1Height"  gencodec:"required"`
+	ID        Decimal64 `json:"id"        gencodec:"required"`
+	Timestamp Decimal64 `json:"timestamp" gencodec:"required"`
+	L1Height  Decimal64 `json:"l1Height"  gencodec:"required"`
 }
 
 //go:generate go run github.com/fjl/gencodec -type EthDeposit -field-override ethDepositMarshaling -out gen_eth_deposit.go
@@ -99,5 +133,5 @@ type EthDeposit struct {
 
 type ethDepositMarshaling struct {
 	Amount *math.HexOrDecimal256 `json:"amount" gencodec:"required"`
-	ID     math.HexOrDecimal64   `json:"id"     gencodec:"required"`
+	ID     Decimal64             `json:"id"     gencodec:"required"`
 }
@@ -55,6 +55,36 @@ func (b *BlockProposedV2) GethType() *ontake.TaikoL1ClientBlockProposedV2 {
 	}
 }
 
+// NewBlockProposedV2 converts a BlockProposedV2 event to the raiko format.
+func NewBlockProposedV2(b *ontake.TaikoL1ClientBlockProposedV2) *BlockProposedV2 {
+	baseFeeConfig := LibSharedDataBaseFeeConfig(b.Meta.BaseFeeConfig)
+	return &BlockProposedV2{
+		BlockID: b.BlockId,
+		Meta: &BlockMetadataV2{
+			AnchorBlockHash:  b.Meta.AnchorBlockHash,
+			Difficulty:       b.Meta.Difficulty,
+			BlobHash:         b.Meta.BlobHash,
+			ExtraData:        b.Meta.ExtraData,
+			Coinbase:         b.Meta.Coinbase,
+			ID:               b.Meta.Id,
+			GasLimit:         b.Meta.GasLimit,
+			Timestamp:        b.Meta.Timestamp,
+			AnchorBlockID:    b.Meta.AnchorBlockId,
+			MinTier:          b.Meta.MinTier,
+			BlobUsed:         b.Meta.BlobUsed,
+			ParentMetaHash:   b.Meta.ParentMetaHash,
+			Proposer:         b.Meta.Proposer,
+			LivenessBond:     b.Meta.LivenessBond,
+			ProposedAt:       b.Meta.ProposedAt,
+			ProposedIn:       b.Meta.ProposedIn,
+			BlobTxListOffset: b.Meta.BlobTxListOffset,
+			BlobTxListLength: b.Meta.BlobTxListLength,
+			BlobIndex:        b.Meta.BlobIndex,
+			BaseFeeConfig:    &baseFeeConfig,
+		},
+	}
+}
+
 type blockProposedV2Marshaling struct {
 	BlockID *math.HexOrDecimal256 `json:"blockId" gencodec:"required"`
 }
@@ -86,10 +116,10 @@ type BlockMetadataV2 struct {
 }
 
 type blockMetadataV2Marshaling struct {
-	ID            math.HexOrDecimal64   `json:"id"            gencodec:"required"`
-	Timestamp     math.HexOrDecimal64   `json:"timestamp"     gencodec:"required"`
-	AnchorBlockID math.HexOrDecimal64   `json:"anchorBlockId" gencodec:"required"`
-	LivenessBond  *math.HexOrDecimal256 `json:"livenessBond"  gencodec:"required"`
-	ProposedAt    math.HexOrDecimal64   `json:"proposedAt"    gencodec:"required"`
-	ProposedIn    math.HexOrDecimal64   `json:"proposedIn"    gencodec:"required"`
+	ID            Decimal64   `json:"id"            gencodec:"required"`
+	Timestamp     Decimal64   `json:"timestamp"     gencodec:"required"`
+	AnchorBlockID Decimal64   `json:"anchorBlockId" gencodec:"required"`
+	LivenessBond  *Decimal256 `json:"livenessBond"  gencodec:"required"`
+	ProposedAt    Decimal64   `json:"proposedAt"    gencodec:"required"`
+	ProposedIn    Decimal64   `json:"proposedIn"    gencodec:"required"`
 }
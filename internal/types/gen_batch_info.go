@@ -7,7 +7,6 @@ import (
 	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/math"
 )
 
 var _ = (*batchInfoMarshaling)(nil)
@@ -20,14 +19,14 @@ func (b BatchInfo) MarshalJSON() ([]byte, error) {
 		BlobHashes         []common.Hash               `json:"blobHashes"         gencodec:"required"`
 		ExtraData          common.Hash                 `json:"extraData"          gencodec:"required"`
 		Coinbase           common.Address              `json:"coinbase"           gencodec:"required"`
-		ProposedIn         math.HexOrDecimal64         `json:"proposedIn"         gencodec:"required"`
+		ProposedIn         Decimal64                   `json:"proposedIn"         gencodec:"required"`
 		BlobByteOffset     uint32                      `json:"blobByteOffset"     gencodec:"required"`
 		BlobByteSize       uint32                      `json:"blobByteSize"       gencodec:"required"`
-		BlobCreatedIn      math.HexOrDecimal64         `json:"blobCreatedIn"      gencodec:"required"`
+		BlobCreatedIn      Decimal64                   `json:"blobCreatedIn"      gencodec:"required"`
 		GasLimit           uint32                      `json:"gasLimit"           gencodec:"required"`
-		LastBlockID        math.HexOrDecimal64         `json:"lastBlockId"        gencodec:"required"`
-		LastBlockTimestamp math.HexOrDecimal64         `json:"lastBlockTimestamp" gencodec:"required"`
-		AnchorBlockID      math.HexOrDecimal64         `json:"anchorBlockId"      gencodec:"required"`
+		LastBlockID        Decimal64                   `json:"lastBlockId"        gencodec:"required"`
+		LastBlockTimestamp Decimal64                   `json:"lastBlockTimestamp" gencodec:"required"`
+		AnchorBlockID      Decimal64                   `json:"anchorBlockId"      gencodec:"required"`
 		AnchorBlockHash    common.Hash                 `json:"anchorBlockHash"    gencodec:"required"`
 		BaseFeeConfig      *LibSharedDataBaseFeeConfig `json:"baseFeeConfig"      gencodec:"required"`
 	}
@@ -42,14 +41,14 @@ func (b BatchInfo) MarshalJSON() ([]byte, error) {
 	}
 	enc.ExtraData = b.ExtraData
 	enc.Coinbase = b.Coinbase
-	enc.ProposedIn = math.HexOrDecimal64(b.ProposedIn)
+	enc.ProposedIn = Decimal64(b.ProposedIn)
 	enc.BlobByteOffset = b.BlobByteOffset
 	enc.BlobByteSize = b.BlobByteSize
-	enc.BlobCreatedIn = math.HexOrDecimal64(b.BlobCreatedIn)
+	enc.BlobCreatedIn = Decimal64(b.BlobCreatedIn)
 	enc.GasLimit = b.GasLimit
-	enc.LastBlockID = math.HexOrDecimal64(b.LastBlockID)
-	enc.LastBlockTimestamp = math.HexOrDecimal64(b.LastBlockTimestamp)
-	enc.AnchorBlockID = math.HexOrDecimal64(b.AnchorBlockID)
+	enc.LastBlockID = Decimal64(b.LastBlockID)
+	enc.LastBlockTimestamp = Decimal64(b.LastBlockTimestamp)
+	enc.AnchorBlockID = Decimal64(b.AnchorBlockID)
 	enc.AnchorBlockHash = b.AnchorBlockHash
 	enc.BaseFeeConfig = b.BaseFeeConfig
 	return json.Marshal(&enc)
@@ -63,14 +62,14 @@ func (b *BatchInfo) UnmarshalJSON(input []byte) error {
 		BlobHashes         []common.Hash               `json:"blobHashes"         gencodec:"required"`
 		ExtraData          *common.Hash                `json:"extraData"          gencodec:"required"`
 		Coinbase           *common.Address             `json:"coinbase"           gencodec:"required"`
-		ProposedIn         *math.HexOrDecimal64        `json:"proposedIn"         gencodec:"required"`
+		ProposedIn         *Decimal64                  `json:"proposedIn"         gencodec:"required"`
 		BlobByteOffset     *uint32                     `json:"blobByteOffset"     gencodec:"required"`
 		BlobByteSize       *uint32                     `json:"blobByteSize"       gencodec:"required"`
-		BlobCreatedIn      *math.HexOrDecimal64        `json:"blobCreatedIn"      gencodec:"required"`
+		BlobCreatedIn      *Decimal64                  `json:"blobCreatedIn"      gencodec:"required"`
 		GasLimit           *uint32                     `json:"gasLimit"           gencodec:"required"`
-		LastBlockID        *math.HexOrDecimal64        `json:"lastBlockId"        gencodec:"required"`
-		LastBlockTimestamp *math.HexOrDecimal64        `json:"lastBlockTimestamp" gencodec:"required"`
-		AnchorBlockID      *math.HexOrDecimal64        `json:"anchorBlockId"      gencodec:"required"`
+		LastBlockID        *Decimal64                  `json:"lastBlockId"        gencodec:"required"`
+		LastBlockTimestamp *Decimal64                  `json:"lastBlockTimestamp" gencodec:"required"`
+		AnchorBlockID      *Decimal64                  `json:"anchorBlockId"      gencodec:"required"`
 		AnchorBlockHash    *common.Hash                `json:"anchorBlockHash"    gencodec:"required"`
 		BaseFeeConfig      *LibSharedDataBaseFeeConfig `json:"baseFeeConfig"      gencodec:"required"`
 	}
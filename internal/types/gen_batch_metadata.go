@@ -7,7 +7,6 @@ import (
 	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/math"
 )
 
 var _ = (*batchMetadataMarshaling)(nil)
@@ -15,26 +14,26 @@ var _ = (*batchMetadataMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (b BatchMetadata) MarshalJSON() ([]byte, error) {
 	type BatchMetadata struct {
-		InfoHash   common.Hash         `json:"infoHash"   gencodec:"required"`
-		Proposer   common.Address      `json:"proposer"   gencodec:"required"`
-		BatchID    math.HexOrDecimal64 `json:"batchId"    gencodec:"required"`
-		ProposedAt math.HexOrDecimal64 `json:"proposedAt" gencodec:"required"`
+		InfoHash   common.Hash    `json:"infoHash"   gencodec:"required"`
+		Proposer   common.Address `json:"proposer"   gencodec:"required"`
+		BatchID    Decimal64      `json:"batchId"    gencodec:"required"`
+		ProposedAt Decimal64      `json:"proposedAt" gencodec:"required"`
 	}
 	var enc BatchMetadata
-	enc.InfoHash = b.InfoHash
+	enc.InfoHash = common.Hash(b.InfoHash)
 	enc.Proposer = b.Proposer
-	enc.BatchID = math.HexOrDecimal64(b.BatchID)
-	enc.ProposedAt = math.HexOrDecimal64(b.ProposedAt)
+	enc.BatchID = Decimal64(b.BatchID)
+	enc.ProposedAt = Decimal64(b.ProposedAt)
 	return json.Marshal(&enc)
 }
 
 // UnmarshalJSON unmarshals from JSON.
 func (b *BatchMetadata) UnmarshalJSON(input []byte) error {
 	type BatchMetadata struct {
-		InfoHash   *common.Hash         `json:"infoHash"   gencodec:"required"`
-		Proposer   *common.Address      `json:"proposer"   gencodec:"required"`
-		BatchID    *math.HexOrDecimal64 `json:"batchId"    gencodec:"required"`
-		ProposedAt *math.HexOrDecimal64 `json:"proposedAt" gencodec:"required"`
+		InfoHash   *common.Hash    `json:"infoHash"   gencodec:"required"`
+		Proposer   *common.Address `json:"proposer"   gencodec:"required"`
+		BatchID    *Decimal64      `json:"batchId"    gencodec:"required"`
+		ProposedAt *Decimal64      `json:"proposedAt" gencodec:"required"`
 	}
 	var dec BatchMetadata
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -43,7 +42,7 @@ func (b *BatchMetadata) UnmarshalJSON(input []byte) error {
 	if dec.InfoHash == nil {
 		return errors.New("missing required field 'infoHash' for BatchMetadata")
 	}
-	b.InfoHash = *dec.InfoHash
+	b.InfoHash = [32]byte(*dec.InfoHash)
 	if dec.Proposer == nil {
 		return errors.New("missing required field 'proposer' for BatchMetadata")
 	}
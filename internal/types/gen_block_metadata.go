@@ -7,7 +7,6 @@ import (
 	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/math"
 )
 
 var _ = (*blockMetadataMarshaling)(nil)
@@ -15,20 +14,20 @@ var _ = (*blockMetadataMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (b BlockMetadata) MarshalJSON() ([]byte, error) {
 	type BlockMetadata struct {
-		L1Hash         common.Hash         `json:"l1Hash"         gencodec:"required"`
-		Difficulty     common.Hash         `json:"difficulty"     gencodec:"required"`
-		BlobHash       common.Hash         `json:"blobHash"       gencodec:"required"`
-		ExtraData      common.Hash         `json:"extraData"      gencodec:"required"`
-		DepositsHash   common.Hash         `json:"depositsHash"   gencodec:"required"`
-		Coinbase       common.Address      `json:"coinbase"       gencodec:"required"`
-		ID             math.HexOrDecimal64 `json:"id"             gencodec:"required"`
-		GasLimit       uint32              `json:"gasLimit"       gencodec:"required"`
-		Timestamp      math.HexOrDecimal64 `json:"timestamp"      gencodec:"required"`
-		L1Height       math.HexOrDecimal64 `json:"l1Height"       gencodec:"required"`
-		MinTier        uint16              `json:"minTier"        gencodec:"required"`
-		BlobUsed       bool                `json:"blobUsed"       gencodec:"required"`
-		ParentMetaHash common.Hash         `json:"parentMetaHash" gencodec:"required"`
-		Sender         common.Address      `json:"sender"         gencodec:"required"`
+		L1Hash         common.Hash    `json:"l1Hash"         gencodec:"required"`
+		Difficulty     common.Hash    `json:"difficulty"     gencodec:"required"`
+		BlobHash       common.Hash    `json:"blobHash"       gencodec:"required"`
+		ExtraData      common.Hash    `json:"extraData"      gencodec:"required"`
+		DepositsHash   common.Hash    `json:"depositsHash"   gencodec:"required"`
+		Coinbase       common.Address `json:"coinbase"       gencodec:"required"`
+		ID             Decimal64      `json:"id"             gencodec:"required"`
+		GasLimit       uint32         `json:"gasLimit"       gencodec:"required"`
+		Timestamp      Decimal64      `json:"timestamp"      gencodec:"required"`
+		L1Height       Decimal64      `json:"l1Height"       gencodec:"required"`
+		MinTier        uint16         `json:"minTier"        gencodec:"required"`
+		BlobUsed       bool           `json:"blobUsed"       gencodec:"required"`
+		ParentMetaHash common.Hash    `json:"parentMetaHash" gencodec:"required"`
+		Sender         common.Address `json:"sender"         gencodec:"required"`
 	}
 	var enc BlockMetadata
 	enc.L1Hash = b.L1Hash
@@ -37,10 +36,10 @@ func (b BlockMetadata) MarshalJSON() ([]byte, error) {
 	enc.ExtraData = b.ExtraData
 	enc.DepositsHash = b.DepositsHash
 	enc.Coinbase = b.Coinbase
-	enc.ID = math.HexOrDecimal64(b.ID)
+	enc.ID = Decimal64(b.ID)
 	enc.GasLimit = b.GasLimit
-	enc.Timestamp = math.HexOrDecimal64(b.Timestamp)
-	enc.L1Height = math.HexOrDecimal64(b.L1Height)
+	enc.Timestamp = Decimal64(b.Timestamp)
+	enc.L1Height = Decimal64(b.L1Height)
 	enc.MinTier = b.MinTier
 	enc.BlobUsed = b.BlobUsed
 	enc.ParentMetaHash = b.ParentMetaHash
@@ -51,20 +50,20 @@ func (b BlockMetadata) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (b *BlockMetadata) UnmarshalJSON(input []byte) error {
 	type BlockMetadata struct {
-		L1Hash         *common.Hash         `json:"l1Hash"         gencodec:"required"`
-		Difficulty     *common.Hash         `json:"difficulty"     gencodec:"required"`
-		BlobHash       *common.Hash         `json:"blobHash"       gencodec:"required"`
-		ExtraData      *common.Hash         `json:"extraData"      gencodec:"required"`
-		DepositsHash   *common.Hash         `json:"depositsHash"   gencodec:"required"`
-		Coinbase       *common.Address      `json:"coinbase"       gencodec:"required"`
-		ID             *math.HexOrDecimal64 `json:"id"             gencodec:"required"`
-		GasLimit       *uint32              `json:"gasLimit"       gencodec:"required"`
-		Timestamp      *math.HexOrDecimal64 `json:"timestamp"      gencodec:"required"`
-		L1Height       *math.HexOrDecimal64 `json:"l1Height"       gencodec:"required"`
-		MinTier        *uint16              `json:"minTier"        gencodec:"required"`
-		BlobUsed       *bool                `json:"blobUsed"       gencodec:"required"`
-		ParentMetaHash *common.Hash         `json:"parentMetaHash" gencodec:"required"`
-		Sender         *common.Address      `json:"sender"         gencodec:"required"`
+		L1Hash         *common.Hash    `json:"l1Hash"         gencodec:"required"`
+		Difficulty     *common.Hash    `json:"difficulty"     gencodec:"required"`
+		BlobHash       *common.Hash    `json:"blobHash"       gencodec:"required"`
+		ExtraData      *common.Hash    `json:"extraData"      gencodec:"required"`
+		DepositsHash   *common.Hash    `json:"depositsHash"   gencodec:"required"`
+		Coinbase       *common.Address `json:"coinbase"       gencodec:"required"`
+		ID             *Decimal64      `json:"id"             gencodec:"required"`
+		GasLimit       *uint32         `json:"gasLimit"       gencodec:"required"`
+		Timestamp      *Decimal64      `json:"timestamp"      gencodec:"required"`
+		L1Height       *Decimal64      `json:"l1Height"       gencodec:"required"`
+		MinTier        *uint16         `json:"minTier"        gencodec:"required"`
+		BlobUsed       *bool           `json:"blobUsed"       gencodec:"required"`
+		ParentMetaHash *common.Hash    `json:"parentMetaHash" gencodec:"required"`
+		Sender         *common.Address `json:"sender"         gencodec:"required"`
 	}
 	var dec BlockMetadata
 	if err := json.Unmarshal(input, &dec); err != nil {
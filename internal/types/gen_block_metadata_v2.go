@@ -8,7 +8,6 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/math"
 )
 
 var _ = (*blockMetadataV2Marshaling)(nil)
@@ -21,17 +20,17 @@ func (b BlockMetadataV2) MarshalJSON() ([]byte, error) {
 		BlobHash         common.Hash                 `json:"blobHash"         gencodec:"required"`
 		ExtraData        common.Hash                 `json:"extraData"        gencodec:"required"`
 		Coinbase         common.Address              `json:"coinbase"         gencodec:"required"`
-		ID               math.HexOrDecimal64         `json:"id"               gencodec:"required"`
+		ID               Decimal64                   `json:"id"               gencodec:"required"`
 		GasLimit         uint32                      `json:"gasLimit"         gencodec:"required"`
-		Timestamp        math.HexOrDecimal64         `json:"timestamp"        gencodec:"required"`
-		AnchorBlockID    math.HexOrDecimal64         `json:"anchorBlockId"    gencodec:"required"`
+		Timestamp        Decimal64                   `json:"timestamp"        gencodec:"required"`
+		AnchorBlockID    Decimal64                   `json:"anchorBlockId"    gencodec:"required"`
 		MinTier          uint16                      `json:"minTier"          gencodec:"required"`
 		BlobUsed         bool                        `json:"blobUsed"         gencodec:"required"`
 		ParentMetaHash   common.Hash                 `json:"parentMetaHash"   gencodec:"required"`
 		Proposer         common.Address              `json:"proposer"         gencodec:"required"`
-		LivenessBond     *math.HexOrDecimal256       `json:"livenessBond"     gencodec:"required"`
-		ProposedAt       math.HexOrDecimal64         `json:"proposedAt"       gencodec:"required"`
-		ProposedIn       math.HexOrDecimal64         `json:"proposedIn"       gencodec:"required"`
+		LivenessBond     *Decimal256                 `json:"livenessBond"     gencodec:"required"`
+		ProposedAt       Decimal64                   `json:"proposedAt"       gencodec:"required"`
+		ProposedIn       Decimal64                   `json:"proposedIn"       gencodec:"required"`
 		BlobTxListOffset uint32                      `json:"blobTxListOffset" gencodec:"required"`
 		BlobTxListLength uint32                      `json:"blobTxListLength" gencodec:"required"`
 		BlobIndex        uint8                       `json:"blobIndex"        gencodec:"required"`
@@ -43,17 +42,17 @@ func (b BlockMetadataV2) MarshalJSON() ([]byte, error) {
 	enc.BlobHash = b.BlobHash
 	enc.ExtraData = b.ExtraData
 	enc.Coinbase = b.Coinbase
-	enc.ID = math.HexOrDecimal64(b.ID)
+	enc.ID = Decimal64(b.ID)
 	enc.GasLimit = b.GasLimit
-	enc.Timestamp = math.HexOrDecimal64(b.Timestamp)
-	enc.AnchorBlockID = math.HexOrDecimal64(b.AnchorBlockID)
+	enc.Timestamp = Decimal64(b.Timestamp)
+	enc.AnchorBlockID = Decimal64(b.AnchorBlockID)
 	enc.MinTier = b.MinTier
 	enc.BlobUsed = b.BlobUsed
 	enc.ParentMetaHash = b.ParentMetaHash
 	enc.Proposer = b.Proposer
-	enc.LivenessBond = (*math.HexOrDecimal256)(b.LivenessBond)
-	enc.ProposedAt = math.HexOrDecimal64(b.ProposedAt)
-	enc.ProposedIn = math.HexOrDecimal64(b.ProposedIn)
+	enc.LivenessBond = (*Decimal256)(b.LivenessBond)
+	enc.ProposedAt = Decimal64(b.ProposedAt)
+	enc.ProposedIn = Decimal64(b.ProposedIn)
 	enc.BlobTxListOffset = b.BlobTxListOffset
 	enc.BlobTxListLength = b.BlobTxListLength
 	enc.BlobIndex = b.BlobIndex
@@ -69,17 +68,17 @@ func (b *BlockMetadataV2) UnmarshalJSON(input []byte) error {
 		BlobHash         *common.Hash                `json:"blobHash"         gencodec:"required"`
 		ExtraData        *common.Hash                `json:"extraData"        gencodec:"required"`
 		Coinbase         *common.Address             `json:"coinbase"         gencodec:"required"`
-		ID               *math.HexOrDecimal64        `json:"id"               gencodec:"required"`
+		ID               *Decimal64                  `json:"id"               gencodec:"required"`
 		GasLimit         *uint32                     `json:"gasLimit"         gencodec:"required"`
-		Timestamp        *math.HexOrDecimal64        `json:"timestamp"        gencodec:"required"`
-		AnchorBlockID    *math.HexOrDecimal64        `json:"anchorBlockId"    gencodec:"required"`
+		Timestamp        *Decimal64                  `json:"timestamp"        gencodec:"required"`
+		AnchorBlockID    *Decimal64                  `json:"anchorBlockId"    gencodec:"required"`
 		MinTier          *uint16                     `json:"minTier"          gencodec:"required"`
 		BlobUsed         *bool                       `json:"blobUsed"         gencodec:"required"`
 		ParentMetaHash   *common.Hash                `json:"parentMetaHash"   gencodec:"required"`
 		Proposer         *common.Address             `json:"proposer"         gencodec:"required"`
-		LivenessBond     *math.HexOrDecimal256       `json:"livenessBond"     gencodec:"required"`
-		ProposedAt       *math.HexOrDecimal64        `json:"proposedAt"       gencodec:"required"`
-		ProposedIn       *math.HexOrDecimal64        `json:"proposedIn"       gencodec:"required"`
+		LivenessBond     *Decimal256                 `json:"livenessBond"     gencodec:"required"`
+		ProposedAt       *Decimal64                  `json:"proposedAt"       gencodec:"required"`
+		ProposedIn       *Decimal64                  `json:"proposedIn"       gencodec:"required"`
 		BlobTxListOffset *uint32                     `json:"blobTxListOffset" gencodec:"required"`
 		BlobTxListLength *uint32                     `json:"blobTxListLength" gencodec:"required"`
 		BlobIndex        *uint8                      `json:"blobIndex"        gencodec:"required"`
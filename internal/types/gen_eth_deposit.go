@@ -18,12 +18,12 @@ func (e EthDeposit) MarshalJSON() ([]byte, error) {
 	type EthDeposit struct {
 		Recipient common.Address        `json:"recipient" gencodec:"required"`
 		Amount    *math.HexOrDecimal256 `json:"amount"    gencodec:"required"`
-		ID        math.HexOrDecimal64   `json:"id"        gencodec:"required"`
+		ID        Decimal64             `json:"id"        gencodec:"required"`
 	}
 	var enc EthDeposit
 	enc.Recipient = e.Recipient
 	enc.Amount = (*math.HexOrDecimal256)(e.Amount)
-	enc.ID = math.HexOrDecimal64(e.ID)
+	enc.ID = Decimal64(e.ID)
 	return json.Marshal(&enc)
 }
 
@@ -32,7 +32,7 @@ func (e *EthDeposit) UnmarshalJSON(input []byte) error {
 	type EthDeposit struct {
 		Recipient *common.Address       `json:"recipient" gencodec:"required"`
 		Amount    *math.HexOrDecimal256 `json:"amount"    gencodec:"required"`
-		ID        *math.HexOrDecimal64  `json:"id"        gencodec:"required"`
+		ID        *Decimal64            `json:"id"        gencodec:"required"`
 	}
 	var dec EthDeposit
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -26,18 +26,19 @@ func (h Header) MarshalJSON() ([]byte, error) {
 		ReceiptHash           common.Hash           `json:"receipts_root"            gencodec:"required"`
 		Bloom                 types.Bloom           `json:"logs_bloom"               gencodec:"required"`
 		Difficulty            *math.HexOrDecimal256 `json:"difficulty"               gencodec:"required"`
-		Number                *math.HexOrDecimal256 `json:"number"                   gencodec:"required"`
-		GasLimit              math.HexOrDecimal64   `json:"gas_limit"                gencodec:"required"`
-		GasUsed               math.HexOrDecimal64   `json:"gas_used"                 gencodec:"required"`
-		Time                  math.HexOrDecimal64   `json:"timestamp"                gencodec:"required"`
+		Number                *Decimal256           `json:"number"                   gencodec:"required"`
+		GasLimit              Decimal64             `json:"gas_limit"                gencodec:"required"`
+		GasUsed               Decimal64             `json:"gas_used"                 gencodec:"required"`
+		Time                  Decimal64             `json:"timestamp"                gencodec:"required"`
 		Extra                 hexutil.Bytes         `json:"extra_data"               gencodec:"required"`
 		MixDigest             common.Hash           `json:"mix_hash"                 gencodec:"required"`
-		Nonce                 math.HexOrDecimal64   `json:"nonce"                    gencodec:"required"`
-		BaseFee               *math.HexOrDecimal256 `json:"base_fee_per_gas"`
+		Nonce                 Decimal64             `json:"nonce"                    gencodec:"required"`
+		BaseFee               *Decimal256           `json:"base_fee_per_gas"`
 		WithdrawalsHash       *common.Hash          `json:"withdrawals_root"`
-		BlobGasUsed           *math.HexOrDecimal64  `json:"blob_gas_used"`
-		ExcessBlobGas         *math.HexOrDecimal64  `json:"excess_blob_gas"`
+		BlobGasUsed           *Decimal64            `json:"blob_gas_used"`
+		ExcessBlobGas         *Decimal64            `json:"excess_blob_gas"`
 		ParentBeaconBlockRoot *common.Hash          `json:"parent_beacon_block_root"`
+		RequestsHash          *common.Hash          `json:"requests_hash,omitempty"`
 		RequestsRoot          *common.Hash          `json:"requests_root"`
 	}
 	var enc Header
@@ -49,18 +50,19 @@ func (h Header) MarshalJSON() ([]byte, error) {
 	enc.ReceiptHash = h.ReceiptHash
 	enc.Bloom = h.Bloom
 	enc.Difficulty = (*math.HexOrDecimal256)(h.Difficulty)
-	enc.Number = (*math.HexOrDecimal256)(h.Number)
-	enc.GasLimit = math.HexOrDecimal64(h.GasLimit)
-	enc.GasUsed = math.HexOrDecimal64(h.GasUsed)
-	enc.Time = math.HexOrDecimal64(h.Time)
+	enc.Number = (*Decimal256)(h.Number)
+	enc.GasLimit = Decimal64(h.GasLimit)
+	enc.GasUsed = Decimal64(h.GasUsed)
+	enc.Time = Decimal64(h.Time)
 	enc.Extra = h.Extra
 	enc.MixDigest = h.MixDigest
-	enc.Nonce = math.HexOrDecimal64(h.Nonce)
-	enc.BaseFee = (*math.HexOrDecimal256)(h.BaseFee)
+	enc.Nonce = Decimal64(h.Nonce)
+	enc.BaseFee = (*Decimal256)(h.BaseFee)
 	enc.WithdrawalsHash = h.WithdrawalsHash
-	enc.BlobGasUsed = (*math.HexOrDecimal64)(h.BlobGasUsed)
-	enc.ExcessBlobGas = (*math.HexOrDecimal64)(h.ExcessBlobGas)
+	enc.BlobGasUsed = (*Decimal64)(h.BlobGasUsed)
+	enc.ExcessBlobGas = (*Decimal64)(h.ExcessBlobGas)
 	enc.ParentBeaconBlockRoot = h.ParentBeaconBlockRoot
+	enc.RequestsHash = h.RequestsHash
 	enc.RequestsRoot = h.RequestsRoot
 	return json.Marshal(&enc)
 }
@@ -76,18 +78,19 @@ func (h *Header) UnmarshalJSON(input []byte) error {
 		ReceiptHash           *common.Hash          `json:"receipts_root"            gencodec:"required"`
 		Bloom                 *types.Bloom          `json:"logs_bloom"               gencodec:"required"`
 		Difficulty            *math.HexOrDecimal256 `json:"difficulty"               gencodec:"required"`
-		Number                *math.HexOrDecimal256 `json:"number"                   gencodec:"required"`
-		GasLimit              *math.HexOrDecimal64  `json:"gas_limit"                gencodec:"required"`
-		GasUsed               *math.HexOrDecimal64  `json:"gas_used"                 gencodec:"required"`
-		Time                  *math.HexOrDecimal64  `json:"timestamp"                gencodec:"required"`
+		Number                *Decimal256           `json:"number"                   gencodec:"required"`
+		GasLimit              *Decimal64            `json:"gas_limit"                gencodec:"required"`
+		GasUsed               *Decimal64            `json:"gas_used"                 gencodec:"required"`
+		Time                  *Decimal64            `json:"timestamp"                gencodec:"required"`
 		Extra                 *hexutil.Bytes        `json:"extra_data"               gencodec:"required"`
 		MixDigest             *common.Hash          `json:"mix_hash"                 gencodec:"required"`
-		Nonce                 *math.HexOrDecimal64  `json:"nonce"                    gencodec:"required"`
-		BaseFee               *math.HexOrDecimal256 `json:"base_fee_per_gas"`
+		Nonce                 *Decimal64            `json:"nonce"                    gencodec:"required"`
+		BaseFee               *Decimal256           `json:"base_fee_per_gas"`
 		WithdrawalsHash       *common.Hash          `json:"withdrawals_root"`
-		BlobGasUsed           *math.HexOrDecimal64  `json:"blob_gas_used"`
-		ExcessBlobGas         *math.HexOrDecimal64  `json:"excess_blob_gas"`
+		BlobGasUsed           *Decimal64            `json:"blob_gas_used"`
+		ExcessBlobGas         *Decimal64            `json:"excess_blob_gas"`
 		ParentBeaconBlockRoot *common.Hash          `json:"parent_beacon_block_root"`
+		RequestsHash          *common.Hash          `json:"requests_hash,omitempty"`
 		RequestsRoot          *common.Hash          `json:"requests_root"`
 	}
 	var dec Header
@@ -169,6 +172,9 @@ func (h *Header) UnmarshalJSON(input []byte) error {
 	if dec.ParentBeaconBlockRoot != nil {
 		h.ParentBeaconBlockRoot = dec.ParentBeaconBlockRoot
 	}
+	if dec.RequestsHash != nil {
+		h.RequestsHash = dec.RequestsHash
+	}
 	if dec.RequestsRoot != nil {
 		h.RequestsRoot = dec.RequestsRoot
 	}
@@ -5,8 +5,6 @@ package types
 import (
 	"encoding/json"
 	"errors"
-
-	"github.com/ethereum/go-ethereum/common/math"
 )
 
 var _ = (*libSharedDataBaseFeeConfigMarshaling)(nil)
@@ -14,17 +12,17 @@ var _ = (*libSharedDataBaseFeeConfigMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (l LibSharedDataBaseFeeConfig) MarshalJSON() ([]byte, error) {
 	type LibSharedDataBaseFeeConfig struct {
-		AdjustmentQuotient     uint8               `json:"adjustmentQuotient"     gencodec:"required"`
-		SharingPctg            uint8               `json:"sharingPctg"            gencodec:"required"`
-		GasIssuancePerSecond   uint32              `json:"gasIssuancePerSecond"   gencodec:"required"`
-		MinGasExcess           math.HexOrDecimal64 `json:"minGasExcess"           gencodec:"required"`
-		MaxGasIssuancePerBlock uint32              `json:"maxGasIssuancePerBlock" gencodec:"required"`
+		AdjustmentQuotient     uint8     `json:"adjustmentQuotient"     gencodec:"required"`
+		SharingPctg            uint8     `json:"sharingPctg"            gencodec:"required"`
+		GasIssuancePerSecond   uint32    `json:"gasIssuancePerSecond"   gencodec:"required"`
+		MinGasExcess           Decimal64 `json:"minGasExcess"           gencodec:"required"`
+		MaxGasIssuancePerBlock uint32    `json:"maxGasIssuancePerBlock" gencodec:"required"`
 	}
 	var enc LibSharedDataBaseFeeConfig
 	enc.AdjustmentQuotient = l.AdjustmentQuotient
 	enc.SharingPctg = l.SharingPctg
 	enc.GasIssuancePerSecond = l.GasIssuancePerSecond
-	enc.MinGasExcess = math.HexOrDecimal64(l.MinGasExcess)
+	enc.MinGasExcess = Decimal64(l.MinGasExcess)
 	enc.MaxGasIssuancePerBlock = l.MaxGasIssuancePerBlock
 	return json.Marshal(&enc)
 }
@@ -32,11 +30,11 @@ func (l LibSharedDataBaseFeeConfig) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (l *LibSharedDataBaseFeeConfig) UnmarshalJSON(input []byte) error {
 	type LibSharedDataBaseFeeConfig struct {
-		AdjustmentQuotient     *uint8               `json:"adjustmentQuotient"     gencodec:"required"`
-		SharingPctg            *uint8               `json:"sharingPctg"            gencodec:"required"`
-		GasIssuancePerSecond   *uint32              `json:"gasIssuancePerSecond"   gencodec:"required"`
-		MinGasExcess           *math.HexOrDecimal64 `json:"minGasExcess"           gencodec:"required"`
-		MaxGasIssuancePerBlock *uint32              `json:"maxGasIssuancePerBlock" gencodec:"required"`
+		AdjustmentQuotient     *uint8     `json:"adjustmentQuotient"     gencodec:"required"`
+		SharingPctg            *uint8     `json:"sharingPctg"            gencodec:"required"`
+		GasIssuancePerSecond   *uint32    `json:"gasIssuancePerSecond"   gencodec:"required"`
+		MinGasExcess           *Decimal64 `json:"minGasExcess"           gencodec:"required"`
+		MaxGasIssuancePerBlock *uint32    `json:"maxGasIssuancePerBlock" gencodec:"required"`
 	}
 	var dec LibSharedDataBaseFeeConfig
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -17,20 +17,20 @@ var _ = (*txEip2930Marshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (t TxEip2930) MarshalJSON() ([]byte, error) {
 	type TxEip2930 struct {
-		ChainID    *math.HexOrDecimal256 `json:"chain_id"    gencodec:"required"`
-		Nonce      math.HexOrDecimal64   `json:"nonce"       gencodec:"required"`
-		GasPrice   *math.HexOrDecimal256 `json:"gas_price"   gencodec:"required"`
-		GasLimit   math.HexOrDecimal64   `json:"gas_limit"   gencodec:"required"`
+		ChainID    *Decimal256           `json:"chain_id"    gencodec:"required"`
+		Nonce      Decimal64             `json:"nonce"       gencodec:"required"`
+		GasPrice   *Decimal256           `json:"gas_price"   gencodec:"required"`
+		GasLimit   Decimal64             `json:"gas_limit"   gencodec:"required"`
 		To         *common.Address       `json:"to"`
 		Value      *math.HexOrDecimal256 `json:"value"       gencodec:"required"`
 		AccessList AccessList            `json:"access_list" gencodec:"required"`
 		Input      hexutil.Bytes         `json:"input"       gencodec:"required"`
 	}
 	var enc TxEip2930
-	enc.ChainID = (*math.HexOrDecimal256)(t.ChainID)
-	enc.Nonce = math.HexOrDecimal64(t.Nonce)
-	enc.GasPrice = (*math.HexOrDecimal256)(t.GasPrice)
-	enc.GasLimit = math.HexOrDecimal64(t.GasLimit)
+	enc.ChainID = (*Decimal256)(t.ChainID)
+	enc.Nonce = Decimal64(t.Nonce)
+	enc.GasPrice = (*Decimal256)(t.GasPrice)
+	enc.GasLimit = Decimal64(t.GasLimit)
 	enc.To = t.To
 	enc.Value = (*math.HexOrDecimal256)(t.Value)
 	enc.AccessList = t.AccessList
@@ -41,10 +41,10 @@ func (t TxEip2930) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (t *TxEip2930) UnmarshalJSON(input []byte) error {
 	type TxEip2930 struct {
-		ChainID    *math.HexOrDecimal256 `json:"chain_id"    gencodec:"required"`
-		Nonce      *math.HexOrDecimal64  `json:"nonce"       gencodec:"required"`
-		GasPrice   *math.HexOrDecimal256 `json:"gas_price"   gencodec:"required"`
-		GasLimit   *math.HexOrDecimal64  `json:"gas_limit"   gencodec:"required"`
+		ChainID    *Decimal256           `json:"chain_id"    gencodec:"required"`
+		Nonce      *Decimal64            `json:"nonce"       gencodec:"required"`
+		GasPrice   *Decimal256           `json:"gas_price"   gencodec:"required"`
+		GasLimit   *Decimal64            `json:"gas_limit"   gencodec:"required"`
 		To         *common.Address       `json:"to"`
 		Value      *math.HexOrDecimal256 `json:"value"       gencodec:"required"`
 		AccessList *AccessList           `json:"access_list" gencodec:"required"`
@@ -17,29 +17,29 @@ var _ = (*txEip4844Marshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (t TxEip4844) MarshalJSON() ([]byte, error) {
 	type TxEip4844 struct {
-		ChainID              *math.HexOrDecimal256 `json:"chain_id"                 gencodec:"required"`
-		Nonce                math.HexOrDecimal64   `json:"nonce"                    gencodec:"required"`
-		GasLimit             math.HexOrDecimal64   `json:"gas_limit"                gencodec:"required"`
-		MaxFeePerGas         *math.HexOrDecimal256 `json:"max_fee_per_gas"          gencodec:"required"`
-		MaxPriorityFeePerGas *math.HexOrDecimal256 `json:"max_priority_fee_per_gas" gencodec:"required"`
+		ChainID              *Decimal256           `json:"chain_id"                 gencodec:"required"`
+		Nonce                Decimal64             `json:"nonce"                    gencodec:"required"`
+		GasLimit             Decimal64             `json:"gas_limit"                gencodec:"required"`
+		MaxFeePerGas         *Decimal256           `json:"max_fee_per_gas"          gencodec:"required"`
+		MaxPriorityFeePerGas *Decimal256           `json:"max_priority_fee_per_gas" gencodec:"required"`
 		To                   common.Address        `json:"to"                       gencodec:"required"`
 		Value                *math.HexOrDecimal256 `json:"value"                    gencodec:"required"`
 		AccessList           AccessList            `json:"access_list"              gencodec:"required"`
 		BlobVersionedHashes  []common.Hash         `json:"blob_versioned_hashes"    gencodec:"required"`
-		MaxFeePerBlobGas     *math.HexOrDecimal256 `json:"max_fee_per_blob_gas"     gencodec:"required"`
+		MaxFeePerBlobGas     *Decimal256           `json:"max_fee_per_blob_gas"     gencodec:"required"`
 		Input                hexutil.Bytes         `json:"input"                    gencodec:"required"`
 	}
 	var enc TxEip4844
-	enc.ChainID = (*math.HexOrDecimal256)(t.ChainID)
-	enc.Nonce = math.HexOrDecimal64(t.Nonce)
-	enc.GasLimit = math.HexOrDecimal64(t.GasLimit)
-	enc.MaxFeePerGas = (*math.HexOrDecimal256)(t.MaxFeePerGas)
-	enc.MaxPriorityFeePerGas = (*math.HexOrDecimal256)(t.MaxPriorityFeePerGas)
+	enc.ChainID = (*Decimal256)(t.ChainID)
+	enc.Nonce = Decimal64(t.Nonce)
+	enc.GasLimit = Decimal64(t.GasLimit)
+	enc.MaxFeePerGas = (*Decimal256)(t.MaxFeePerGas)
+	enc.MaxPriorityFeePerGas = (*Decimal256)(t.MaxPriorityFeePerGas)
 	enc.To = t.To
 	enc.Value = (*math.HexOrDecimal256)(t.Value)
 	enc.AccessList = t.AccessList
 	enc.BlobVersionedHashes = t.BlobVersionedHashes
-	enc.MaxFeePerBlobGas = (*math.HexOrDecimal256)(t.MaxFeePerBlobGas)
+	enc.MaxFeePerBlobGas = (*Decimal256)(t.MaxFeePerBlobGas)
 	enc.Input = t.Input
 	return json.Marshal(&enc)
 }
@@ -47,16 +47,16 @@ func (t TxEip4844) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (t *TxEip4844) UnmarshalJSON(input []byte) error {
 	type TxEip4844 struct {
-		ChainID              *math.HexOrDecimal256 `json:"chain_id"                 gencodec:"required"`
-		Nonce                *math.HexOrDecimal64  `json:"nonce"                    gencodec:"required"`
-		GasLimit             *math.HexOrDecimal64  `json:"gas_limit"                gencodec:"required"`
-		MaxFeePerGas         *math.HexOrDecimal256 `json:"max_fee_per_gas"          gencodec:"required"`
-		MaxPriorityFeePerGas *math.HexOrDecimal256 `json:"max_priority_fee_per_gas" gencodec:"required"`
+		ChainID              *Decimal256           `json:"chain_id"                 gencodec:"required"`
+		Nonce                *Decimal64            `json:"nonce"                    gencodec:"required"`
+		GasLimit             *Decimal64            `json:"gas_limit"                gencodec:"required"`
+		MaxFeePerGas         *Decimal256           `json:"max_fee_per_gas"          gencodec:"required"`
+		MaxPriorityFeePerGas *Decimal256           `json:"max_priority_fee_per_gas" gencodec:"required"`
 		To                   *common.Address       `json:"to"                       gencodec:"required"`
 		Value                *math.HexOrDecimal256 `json:"value"                    gencodec:"required"`
 		AccessList           *AccessList           `json:"access_list"              gencodec:"required"`
 		BlobVersionedHashes  []common.Hash         `json:"blob_versioned_hashes"    gencodec:"required"`
-		MaxFeePerBlobGas     *math.HexOrDecimal256 `json:"max_fee_per_blob_gas"     gencodec:"required"`
+		MaxFeePerBlobGas     *Decimal256           `json:"max_fee_per_blob_gas"     gencodec:"required"`
 		Input                *hexutil.Bytes        `json:"input"                    gencodec:"required"`
 	}
 	var dec TxEip4844
@@ -17,19 +17,19 @@ var _ = (*txLegacyMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (t TxLegacy) MarshalJSON() ([]byte, error) {
 	type TxLegacy struct {
-		ChainID  *math.HexOrDecimal256 `json:"chain_id"`
-		Nonce    math.HexOrDecimal64   `json:"nonce"     gencodec:"required"`
-		GasPrice *math.HexOrDecimal256 `json:"gas_price" gencodec:"required"`
-		GasLimit math.HexOrDecimal64   `json:"gas_limit" gencodec:"required"`
+		ChainID  *Decimal256           `json:"chain_id"`
+		Nonce    Decimal64             `json:"nonce"     gencodec:"required"`
+		GasPrice *Decimal256           `json:"gas_price" gencodec:"required"`
+		GasLimit Decimal64             `json:"gas_limit" gencodec:"required"`
 		To       *common.Address       `json:"to"`
 		Value    *math.HexOrDecimal256 `json:"value"     gencodec:"required"`
 		Input    hexutil.Bytes         `json:"input"     gencodec:"required"`
 	}
 	var enc TxLegacy
-	enc.ChainID = (*math.HexOrDecimal256)(t.ChainID)
-	enc.Nonce = math.HexOrDecimal64(t.Nonce)
-	enc.GasPrice = (*math.HexOrDecimal256)(t.GasPrice)
-	enc.GasLimit = math.HexOrDecimal64(t.GasLimit)
+	enc.ChainID = (*Decimal256)(t.ChainID)
+	enc.Nonce = Decimal64(t.Nonce)
+	enc.GasPrice = (*Decimal256)(t.GasPrice)
+	enc.GasLimit = Decimal64(t.GasLimit)
 	enc.To = t.To
 	enc.Value = (*math.HexOrDecimal256)(t.Value)
 	enc.Input = t.Input
@@ -39,10 +39,10 @@ func (t TxLegacy) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (t *TxLegacy) UnmarshalJSON(input []byte) error {
 	type TxLegacy struct {
-		ChainID  *math.HexOrDecimal256 `json:"chain_id"`
-		Nonce    *math.HexOrDecimal64  `json:"nonce"     gencodec:"required"`
-		GasPrice *math.HexOrDecimal256 `json:"gas_price" gencodec:"required"`
-		GasLimit *math.HexOrDecimal64  `json:"gas_limit" gencodec:"required"`
+		ChainID  *Decimal256           `json:"chain_id"`
+		Nonce    *Decimal64            `json:"nonce"     gencodec:"required"`
+		GasPrice *Decimal256           `json:"gas_price" gencodec:"required"`
+		GasLimit *Decimal64            `json:"gas_limit" gencodec:"required"`
 		To       *common.Address       `json:"to"`
 		Value    *math.HexOrDecimal256 `json:"value"     gencodec:"required"`
 		Input    *hexutil.Bytes        `json:"input"     gencodec:"required"`
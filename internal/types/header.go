@@ -24,6 +24,15 @@ func (h Headers) GethType() []*types.Header {
 	return headers
 }
 
+// NewHeaders converts geth headers to the raiko format.
+func NewHeaders(h []*types.Header) Headers {
+	headers := make(Headers, len(h))
+	for i, header := range h {
+		headers[i] = NewHeader(header)
+	}
+	return headers
+}
+
 //go:generate go run github.com/fjl/gencodec -type Header -field-override headerMarshaling -out gen_header.go
 
 // Header represents a block header has the same format with raiko
@@ -48,21 +57,23 @@ type Header struct {
 	BlobGasUsed           *uint64        `json:"blob_gas_used"`
 	ExcessBlobGas         *uint64        `json:"excess_blob_gas"`
 	ParentBeaconBlockRoot *common.Hash   `json:"parent_beacon_block_root"`
-	// BYPASS: This is a workaround for the fact that the `requests_root`(requests_hash) field, same name with raiko-host
+	RequestsHash          *common.Hash   `json:"requests_hash,omitempty"`
+	// BYPASS: raiko-host sends the requests_hash as `requests_root`, it is
+	// the name headers are written with
 	RequestsRoot *common.Hash `json:"requests_root"`
 }
 
 type headerMarshaling struct {
 	Difficulty    *math.HexOrDecimal256 `json:"difficulty"       gencodec:"required"`
-	Number        *math.HexOrDecimal256 `json:"number"           gencodec:"required"`
-	GasLimit      math.HexOrDecimal64   `json:"gas_limit"        gencodec:"required"`
-	GasUsed       math.HexOrDecimal64   `json:"gas_used"         gencodec:"required"`
-	Time          math.HexOrDecimal64   `json:"timestamp"        gencodec:"required"`
+	Number        *Decimal256           `json:"number"           gencodec:"required"`
+	GasLimit      Decimal64             `json:"gas_limit"        gencodec:"required"`
+	GasUsed       Decimal64             `json:"gas_used"         gencodec:"required"`
+	Time          Decimal64             `json:"timestamp"        gencodec:"required"`
 	Extra         hexutil.Bytes         `json:"extra_data"       gencodec:"required"`
-	Nonce         math.HexOrDecimal64   `json:"nonce"            gencodec:"required"`
-	BaseFee       *math.HexOrDecimal256 `json:"base_fee_per_gas"`
-	BlobGasUsed   *math.HexOrDecimal64  `json:"blob_gas_used"`
-	ExcessBlobGas *math.HexOrDecimal64  `json:"excess_blob_gas"`
+	Nonce         Decimal64             `json:"nonce"            gencodec:"required"`
+	BaseFee       *Decimal256           `json:"base_fee_per_gas"`
+	BlobGasUsed   *Decimal64            `json:"blob_gas_used"`
+	ExcessBlobGas *Decimal64            `json:"excess_blob_gas"`
 }
 
 func (h *Header) GethType() *types.Header {
@@ -91,6 +102,45 @@ func (h *Header) GethType() *types.Header {
 		BlobGasUsed:      h.BlobGasUsed,
 		ExcessBlobGas:    h.ExcessBlobGas,
 		ParentBeaconRoot: h.ParentBeaconBlockRoot,
-		RequestsHash:     h.RequestsRoot,
+		RequestsHash:     h.requestsHash(),
+	}
+}
+
+// NewHeader converts a geth header to the raiko format.
+func NewHeader(h *types.Header) *Header {
+	if h == nil {
+		return nil
+	}
+	return &Header{
+		ParentHash:            h.ParentHash,
+		OmmerHash:             h.UncleHash,
+		Coinbase:              h.Coinbase,
+		Root:                  h.Root,
+		TxHash:                h.TxHash,
+		ReceiptHash:           h.ReceiptHash,
+		Bloom:                 h.Bloom,
+		Difficulty:            h.Difficulty,
+		Number:                h.Number,
+		GasLimit:              h.GasLimit,
+		GasUsed:               h.GasUsed,
+		Time:                  h.Time,
+		Extra:                 h.Extra,
+		MixDigest:             h.MixDigest,
+		Nonce:                 h.Nonce.Uint64(),
+		BaseFee:               h.BaseFee,
+		WithdrawalsHash:       h.WithdrawalsHash,
+		BlobGasUsed:           h.BlobGasUsed,
+		ExcessBlobGas:         h.ExcessBlobGas,
+		ParentBeaconBlockRoot: h.ParentBeaconRoot,
+		RequestsRoot:          h.RequestsHash,
+	}
+}
+
+// requestsHash returns the EIP-7685 requests hash from either of the names
+// raiko-host has used for it.
+func (h *Header) requestsHash() *common.Hash {
+	if h.RequestsHash != nil {
+		return h.RequestsHash
 	}
+	return h.RequestsRoot
 }
@@ -1,12 +1,47 @@
 package types
 
 import (
-	"fmt"
+	"encoding/json"
+	"os"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEmptyHash(t *testing.T) {
-	fmt.Printf("%#v\n", types.EmptyReceiptsHash.Bytes())
+	assert.Equal(t, common.HexToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"), types.EmptyReceiptsHash)
+}
+
+// TestHeaderHash checks the hash of real headers of every generation: the
+// mainnet and holesky genesis for frontier and london, a taiko block for
+// shanghai, then cancun, prague and a Taiko mainnet block. Their hashes come
+// from the chains, the anchor block hash of a proposal or the parent hash of
+// the next block.
+func TestHeaderHash(t *testing.T) {
+	data, err := os.ReadFile("testdata/headers.json")
+	require.NoError(t, err)
+	var fixtures []struct {
+		Name   string      `json:"name"`
+		Hash   common.Hash `json:"hash"`
+		Header *Header     `json:"header"`
+	}
+	require.NoError(t, json.Unmarshal(data, &fixtures))
+	require.NotEmpty(t, fixtures)
+
+	for _, fixture := range fixtures {
+		t.Run(fixture.Name, func(t *testing.T) {
+			header := fixture.Header.GethType()
+			assert.Equal(t, fixture.Hash, header.Hash())
+
+			// the raiko header must survive a json round trip
+			encoded, err := json.Marshal(fixture.Header)
+			require.NoError(t, err)
+			var decoded Header
+			require.NoError(t, json.Unmarshal(encoded, &decoded))
+			assert.Equal(t, fixture.Hash, decoded.GethType().Hash())
+		})
+	}
 }
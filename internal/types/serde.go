@@ -0,0 +1,35 @@
+package types
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// The types below mirror how raiko (serde) serializes integers and byte vectors:
+// primitive integers are JSON numbers, `Vec<u8>` is serde.ByteList.
+// Decoding stays lenient and also accepts the hex or decimal strings handled by
+// math.HexOrDecimal64 and math.HexOrDecimal256.
+
+// Decimal64 marshals as a JSON number.
+type Decimal64 uint64
+
+func (d Decimal64) MarshalJSON() ([]byte, error) {
+	return strconv.AppendUint(nil, uint64(d), 10), nil
+}
+
+func (d *Decimal64) UnmarshalJSON(input []byte) error {
+	return (*math.HexOrDecimal64)(d).UnmarshalJSON(input)
+}
+
+// Decimal256 marshals as a JSON number.
+type Decimal256 big.Int
+
+func (d *Decimal256) MarshalJSON() ([]byte, error) {
+	return (*big.Int)(d).Append(nil, 10), nil
+}
+
+func (d *Decimal256) UnmarshalJSON(input []byte) error {
+	return (*math.HexOrDecimal256)(d).UnmarshalJSON(input)
+}
@@ -0,0 +1,177 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+//go:generate go run github.com/fjl/gencodec -type ShastaProposed -out gen_shasta_proposed.go
+
+// ShastaProposed represents a Proposed event raised by the Shasta Inbox contract,
+// together with the transition context raiko attaches to it.
+type ShastaProposed struct {
+	Proposal             *ShastaProposal   `json:"proposal"             gencodec:"required"`
+	Derivation           *ShastaDerivation `json:"derivation"           gencodec:"required"`
+	ParentTransitionHash common.Hash       `json:"parentTransitionHash" gencodec:"required"`
+	DesignatedProver     common.Address    `json:"designatedProver"     gencodec:"required"`
+}
+
+func (s *ShastaProposed) GethType() *InboxProposed {
+	if s == nil {
+		log.Warn("missing ShastaProposed when converting to GethType")
+		return nil
+	}
+	return &InboxProposed{
+		Proposal: IInboxProposal{
+			Id:                             new(big.Int).SetUint64(s.Proposal.ID),
+			Timestamp:                      new(big.Int).SetUint64(s.Proposal.Timestamp),
+			EndOfSubmissionWindowTimestamp: new(big.Int).SetUint64(s.Proposal.EndOfSubmissionWindowTimestamp),
+			Proposer:                       s.Proposal.Proposer,
+			CoreStateHash:                  s.Proposal.CoreStateHash,
+			DerivationHash:                 s.Proposal.DerivationHash,
+		},
+		Derivation: IInboxDerivation{
+			OriginBlockNumber:  new(big.Int).SetUint64(s.Derivation.OriginBlockNumber),
+			OriginBlockHash:    s.Derivation.OriginBlockHash,
+			IsForcedInclusion:  s.Derivation.IsForcedInclusion,
+			BasefeeSharingPctg: s.Derivation.BasefeeSharingPctg,
+			BlobSlice: LibBlobsBlobSlice{
+				BlobHashes: s.Derivation.BlobSlice.BlobHashes,
+				Offset:     new(big.Int).SetUint64(uint64(s.Derivation.BlobSlice.Offset)),
+				Timestamp:  new(big.Int).SetUint64(s.Derivation.BlobSlice.Timestamp),
+			},
+		},
+		ParentTransitionHash: s.ParentTransitionHash,
+		DesignatedProver:     s.DesignatedProver,
+	}
+}
+
+// NewShastaProposed converts a Proposed event to the raiko format.
+func NewShastaProposed(p *InboxProposed) *ShastaProposed {
+	return &ShastaProposed{
+		Proposal: &ShastaProposal{
+			ID:                             p.Proposal.Id.Uint64(),
+			Timestamp:                      p.Proposal.Timestamp.Uint64(),
+			EndOfSubmissionWindowTimestamp: p.Proposal.EndOfSubmissionWindowTimestamp.Uint64(),
+			Proposer:                       p.Proposal.Proposer,
+			CoreStateHash:                  p.Proposal.CoreStateHash,
+			DerivationHash:                 p.Proposal.DerivationHash,
+		},
+		Derivation: &ShastaDerivation{
+			OriginBlockNumber:  p.Derivation.OriginBlockNumber.Uint64(),
+			OriginBlockHash:    p.Derivation.OriginBlockHash,
+			IsForcedInclusion:  p.Derivation.IsForcedInclusion,
+			BasefeeSharingPctg: p.Derivation.BasefeeSharingPctg,
+			BlobSlice: &ShastaBlobSlice{
+				BlobHashes: p.Derivation.BlobSlice.BlobHashes,
+				Offset:     uint32(p.Derivation.BlobSlice.Offset.Uint64()),
+				Timestamp:  p.Derivation.BlobSlice.Timestamp.Uint64(),
+			},
+		},
+		ParentTransitionHash: p.ParentTransitionHash,
+		DesignatedProver:     p.DesignatedProver,
+	}
+}
+
+//go:generate go run github.com/fjl/gencodec -type ShastaProposal -field-override shastaProposalMarshaling -out gen_shasta_proposal.go
+
+// ShastaProposal is the proposal committed by the Shasta Inbox contract.
+type ShastaProposal struct {
+	ID                             uint64         `json:"id"                             gencodec:"required"`
+	Timestamp                      uint64         `json:"timestamp"                      gencodec:"required"`
+	EndOfSubmissionWindowTimestamp uint64         `json:"endOfSubmissionWindowTimestamp" gencodec:"required"`
+	Proposer                       common.Address `json:"proposer"                       gencodec:"required"`
+	CoreStateHash                  common.Hash    `json:"coreStateHash"                  gencodec:"required"`
+	DerivationHash                 common.Hash    `json:"derivationHash"                 gencodec:"required"`
+}
+
+type shastaProposalMarshaling struct {
+	ID                             Decimal64 `json:"id"                             gencodec:"required"`
+	Timestamp                      Decimal64 `json:"timestamp"                      gencodec:"required"`
+	EndOfSubmissionWindowTimestamp Decimal64 `json:"endOfSubmissionWindowTimestamp" gencodec:"required"`
+}
+
+//go:generate go run github.com/fjl/gencodec -type ShastaDerivation -field-override shastaDerivationMarshaling -out gen_shasta_derivation.go
+
+// ShastaDerivation contains the L1 data needed to derive the L2 blocks of a proposal.
+type ShastaDerivation struct {
+	OriginBlockNumber  uint64           `json:"originBlockNumber"  gencodec:"required"`
+	OriginBlockHash    common.Hash      `json:"originBlockHash"    gencodec:"required"`
+	IsForcedInclusion  bool             `json:"isForcedInclusion"  gencodec:"required"`
+	BasefeeSharingPctg uint8            `json:"basefeeSharingPctg" gencodec:"required"`
+	BlobSlice          *ShastaBlobSlice `json:"blobSlice"          gencodec:"required"`
+}
+
+type shastaDerivationMarshaling struct {
+	OriginBlockNumber Decimal64 `json:"originBlockNumber" gencodec:"required"`
+}
+
+//go:generate go run github.com/fjl/gencodec -type ShastaBlobSlice -field-override shastaBlobSliceMarshaling -out gen_shasta_blob_slice.go
+
+// ShastaBlobSlice locates the proposal data inside the blobs of the proposing transaction.
+type ShastaBlobSlice struct {
+	BlobHashes [][32]byte `json:"blobHashes" gencodec:"required"`
+	Offset     uint32     `json:"offset"     gencodec:"required"`
+	Timestamp  uint64     `json:"timestamp"  gencodec:"required"`
+}
+
+type shastaBlobSliceMarshaling struct {
+	BlobHashes []common.Hash `json:"blobHashes" gencodec:"required"`
+	Timestamp  Decimal64     `json:"timestamp"  gencodec:"required"`
+}
+
+// The structs below mirror the abigen bindings of the Shasta Inbox contract,
+// which are not part of the taiko-client bindings yet. Field names follow the
+// solidity components so that they can be packed with go-ethereum's abi.
+
+// InboxProposed is the decoded Proposed event of the Shasta Inbox contract.
+type InboxProposed struct {
+	Proposal             IInboxProposal
+	Derivation           IInboxDerivation
+	ParentTransitionHash [32]byte
+	DesignatedProver     common.Address
+}
+
+// IInboxProposal is an auto generated low-level Go binding around an user-defined struct.
+type IInboxProposal struct {
+	Id                             *big.Int
+	Timestamp                      *big.Int
+	EndOfSubmissionWindowTimestamp *big.Int
+	Proposer                       common.Address
+	CoreStateHash                  [32]byte
+	DerivationHash                 [32]byte
+}
+
+// IInboxDerivation is an auto generated low-level Go binding around an user-defined struct.
+type IInboxDerivation struct {
+	OriginBlockNumber  *big.Int
+	OriginBlockHash    [32]byte
+	IsForcedInclusion  bool
+	BasefeeSharingPctg uint8
+	BlobSlice          LibBlobsBlobSlice
+}
+
+// LibBlobsBlobSlice is an auto generated low-level Go binding around an user-defined struct.
+type LibBlobsBlobSlice struct {
+	BlobHashes [][32]byte
+	Offset     *big.Int
+	Timestamp  *big.Int
+}
+
+// IInboxTransition is an auto generated low-level Go binding around an user-defined struct.
+type IInboxTransition struct {
+	ProposalHash         [32]byte
+	ParentTransitionHash [32]byte
+	Checkpoint           ICheckpointStoreCheckpoint
+	DesignatedProver     common.Address
+	ActualProver         common.Address
+}
+
+// ICheckpointStoreCheckpoint is an auto generated low-level Go binding around an user-defined struct.
+type ICheckpointStoreCheckpoint struct {
+	BlockNumber *big.Int
+	BlockHash   [32]byte
+	StateRoot   [32]byte
+}
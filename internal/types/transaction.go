@@ -27,6 +27,19 @@ func (t TransactionSignedList) GethType() []*types.Transaction {
 	return txs
 }
 
+// NewTransactionSignedList converts geth transactions to the raiko format.
+func NewTransactionSignedList(txs []*types.Transaction) (TransactionSignedList, error) {
+	list := make(TransactionSignedList, len(txs))
+	for i, tx := range txs {
+		signed, err := NewTransactionSigned(tx)
+		if err != nil {
+			return nil, fmt.Errorf("tx %d: %w", i, err)
+		}
+		list[i] = signed
+	}
+	return list, nil
+}
+
 //go:generate go run github.com/fjl/gencodec -type TransactionSigned -out gen_transaction_signed.go
 type TransactionSigned struct {
 	Hash        common.Hash  `json:"hash"        gencodec:"required"`
@@ -106,11 +119,139 @@ func (t *TransactionSigned) GethType() *types.Transaction {
 			S:          uint256.MustFromBig(t.Signature.S),
 		}
 		return types.NewTx(tx)
+	case *TxEip7702:
+		tx := &types.SetCodeTx{
+			ChainID:    uint256.MustFromBig(inner.ChainID),
+			Nonce:      inner.Nonce,
+			GasTipCap:  uint256.MustFromBig(inner.MaxPriorityFeePerGas),
+			GasFeeCap:  uint256.MustFromBig(inner.MaxFeePerGas),
+			Gas:        inner.GasLimit,
+			To:         inner.To,
+			Value:      uint256.MustFromBig(inner.Value),
+			Data:       inner.Input,
+			AccessList: inner.AccessList.GethType(),
+			AuthList:   inner.AuthorizationList.GethType(),
+			V:          uint256.MustFromBig(t.Signature.V(inner.ChainID, false)),
+			R:          uint256.MustFromBig(t.Signature.R),
+			S:          uint256.MustFromBig(t.Signature.S),
+		}
+		return types.NewTx(tx)
 	default:
 		panic(fmt.Sprintf("unknown transaction type: %T", inner))
 	}
 }
 
+// NewTransactionSigned converts a geth transaction to the raiko format, raiko
+// has no variant for the other transaction types.
+func NewTransactionSigned(tx *types.Transaction) (*TransactionSigned, error) {
+	if tx == nil {
+		return nil, nil
+	}
+	v, r, s := tx.RawSignatureValues()
+	var inner any
+	switch tx.Type() {
+	case types.LegacyTxType:
+		var chainID *big.Int
+		if tx.Protected() {
+			chainID = tx.ChainId()
+		}
+		inner = &TxLegacy{
+			ChainID:  chainID,
+			Nonce:    tx.Nonce(),
+			GasPrice: tx.GasPrice(),
+			GasLimit: tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Input:    tx.Data(),
+		}
+	case types.AccessListTxType:
+		inner = &TxEip2930{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasPrice:   tx.GasPrice(),
+			GasLimit:   tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			AccessList: NewAccessList(tx.AccessList()),
+			Input:      tx.Data(),
+		}
+	case types.DynamicFeeTxType:
+		inner = &TxEip1559{
+			ChainID:              tx.ChainId(),
+			Nonce:                tx.Nonce(),
+			GasLimit:             tx.Gas(),
+			MaxFeePerGas:         tx.GasFeeCap(),
+			MaxPriorityFeePerGas: tx.GasTipCap(),
+			To:                   tx.To(),
+			Value:                tx.Value(),
+			AccessList:           NewAccessList(tx.AccessList()),
+			Input:                tx.Data(),
+		}
+	case types.BlobTxType:
+		inner = &TxEip4844{
+			ChainID:              tx.ChainId(),
+			Nonce:                tx.Nonce(),
+			GasLimit:             tx.Gas(),
+			MaxFeePerGas:         tx.GasFeeCap(),
+			MaxPriorityFeePerGas: tx.GasTipCap(),
+			To:                   *tx.To(),
+			Value:                tx.Value(),
+			AccessList:           NewAccessList(tx.AccessList()),
+			BlobVersionedHashes:  tx.BlobHashes(),
+			MaxFeePerBlobGas:     tx.BlobGasFeeCap(),
+			Input:                tx.Data(),
+		}
+	case types.SetCodeTxType:
+		inner = &TxEip7702{
+			ChainID:              tx.ChainId(),
+			Nonce:                tx.Nonce(),
+			GasLimit:             tx.Gas(),
+			MaxFeePerGas:         tx.GasFeeCap(),
+			MaxPriorityFeePerGas: tx.GasTipCap(),
+			To:                   *tx.To(),
+			Value:                tx.Value(),
+			AccessList:           NewAccessList(tx.AccessList()),
+			AuthorizationList:    NewAuthorizationList(tx.SetCodeAuthorizations()),
+			Input:                tx.Data(),
+		}
+	default:
+		return nil, fmt.Errorf("unknown transaction type: %d", tx.Type())
+	}
+	// legacy v is {27, 28} or {35, 36} + CHAIN_ID * 2, both odd for an even y-parity
+	oddYParity := v.Bit(0) == 1
+	if tx.Type() == types.LegacyTxType {
+		oddYParity = v.Bit(0) == 0
+	}
+	return &TransactionSigned{
+		Hash: tx.Hash(),
+		Signature: &Signature{
+			R:          r,
+			S:          s,
+			OddYParity: oddYParity,
+		},
+		Transaction: &Transaction{inner},
+	}, nil
+}
+
+func (t *Transaction) MarshalJSON() ([]byte, error) {
+	var key string
+	switch t.inner.(type) {
+	case *TxLegacy:
+		key = "Legacy"
+	case *TxEip2930:
+		key = "Eip2930"
+	case *TxEip1559:
+		key = "Eip1559"
+	case *TxEip4844:
+		key = "Eip4844"
+	case *TxEip7702:
+		key = "Eip7702"
+	default:
+		return nil, fmt.Errorf("unknown transaction type: %T", t.inner)
+	}
+	return json.Marshal(map[string]any{key: t.inner})
+}
+
 func (t *Transaction) UnmarshalJSON(input []byte) error {
 	raw := map[string]json.RawMessage{}
 	if err := json.Unmarshal(input, &raw); err != nil {
@@ -142,6 +283,12 @@ func (t *Transaction) UnmarshalJSON(input []byte) error {
 				return err
 			}
 			t.inner = &inner
+		case "Eip7702":
+			var inner TxEip7702
+			if err := json.Unmarshal(val, &inner); err != nil {
+				return err
+			}
+			t.inner = &inner
 		default:
 			return fmt.Errorf("unknown transaction type: %s", key)
 		}
@@ -162,10 +309,10 @@ type TxLegacy struct {
 }
 
 type txLegacyMarshaling struct {
-	ChainID  *math.HexOrDecimal256 `json:"chain_id"`
-	Nonce    math.HexOrDecimal64   `json:"nonce"     gencodec:"required"`
-	GasPrice *math.HexOrDecimal256 `json:"gas_price" gencodec:"required"`
-	GasLimit math.HexOrDecimal64   `json:"gas_limit" gencodec:"required"`
+	ChainID  *Decimal256           `json:"chain_id"`
+	Nonce    Decimal64             `json:"nonce"     gencodec:"required"`
+	GasPrice *Decimal256           `json:"gas_price" gencodec:"required"`
+	GasLimit Decimal64             `json:"gas_limit" gencodec:"required"`
 	Value    *math.HexOrDecimal256 `json:"value"     gencodec:"required"`
 	Input    hexutil.Bytes         `json:"input"     gencodec:"required"`
 }
@@ -184,10 +331,10 @@ type TxEip2930 struct {
 }
 
 type txEip2930Marshaling struct {
-	ChainID  *math.HexOrDecimal256 `json:"chain_id"  gencodec:"required"`
-	Nonce    math.HexOrDecimal64   `json:"nonce"     gencodec:"required"`
-	GasPrice *math.HexOrDecimal256 `json:"gas_price" gencodec:"required"`
-	GasLimit math.HexOrDecimal64   `json:"gas_limit" gencodec:"required"`
+	ChainID  *Decimal256           `json:"chain_id"  gencodec:"required"`
+	Nonce    Decimal64             `json:"nonce"     gencodec:"required"`
+	GasPrice *Decimal256           `json:"gas_price" gencodec:"required"`
+	GasLimit Decimal64             `json:"gas_limit" gencodec:"required"`
 	Value    *math.HexOrDecimal256 `json:"value"     gencodec:"required"`
 	Input    hexutil.Bytes         `json:"input"     gencodec:"required"`
 }
@@ -207,11 +354,11 @@ type TxEip1559 struct {
 }
 
 type txEip1559Marshaling struct {
-	ChainID              *math.HexOrDecimal256 `json:"chain_id"                 gencodec:"required"`
-	Nonce                math.HexOrDecimal64   `json:"nonce"                    gencodec:"required"`
-	GasLimit             math.HexOrDecimal64   `json:"gas_limit"                gencodec:"required"`
-	MaxFeePerGas         *math.HexOrDecimal256 `json:"max_fee_per_gas"          gencodec:"required"`
-	MaxPriorityFeePerGas *math.HexOrDecimal256 `json:"max_priority_fee_per_gas" gencodec:"required"`
+	ChainID              *Decimal256           `json:"chain_id"                 gencodec:"required"`
+	Nonce                Decimal64             `json:"nonce"                    gencodec:"required"`
+	GasLimit             Decimal64             `json:"gas_limit"                gencodec:"required"`
+	MaxFeePerGas         *Decimal256           `json:"max_fee_per_gas"          gencodec:"required"`
+	MaxPriorityFeePerGas *Decimal256           `json:"max_priority_fee_per_gas" gencodec:"required"`
 	Value                *math.HexOrDecimal256 `json:"value"                    gencodec:"required"`
 	Input                hexutil.Bytes         `json:"input"                    gencodec:"required"`
 }
@@ -234,12 +381,37 @@ type TxEip4844 struct {
 }
 
 type txEip4844Marshaling struct {
-	ChainID              *math.HexOrDecimal256 `json:"chain_id"                 gencodec:"required"`
-	Nonce                math.HexOrDecimal64   `json:"nonce"                    gencodec:"required"`
-	GasLimit             math.HexOrDecimal64   `json:"gas_limit"                gencodec:"required"`
-	MaxFeePerGas         *math.HexOrDecimal256 `json:"max_fee_per_gas"          gencodec:"required"`
-	MaxPriorityFeePerGas *math.HexOrDecimal256 `json:"max_priority_fee_per_gas" gencodec:"required"`
+	ChainID              *Decimal256           `json:"chain_id"                 gencodec:"required"`
+	Nonce                Decimal64             `json:"nonce"                    gencodec:"required"`
+	GasLimit             Decimal64             `json:"gas_limit"                gencodec:"required"`
+	MaxFeePerGas         *Decimal256           `json:"max_fee_per_gas"          gencodec:"required"`
+	MaxPriorityFeePerGas *Decimal256           `json:"max_priority_fee_per_gas" gencodec:"required"`
+	Value                *math.HexOrDecimal256 `json:"value"                    gencodec:"required"`
+	MaxFeePerBlobGas     *Decimal256           `json:"max_fee_per_blob_gas"     gencodec:"required"`
+	Input                hexutil.Bytes         `json:"input"                    gencodec:"required"`
+}
+
+//go:generate go run github.com/fjl/gencodec -type TxEip7702 -field-override txEip7702Marshaling -out gen_tx_eip7702.go
+
+type TxEip7702 struct {
+	ChainID              *big.Int          `json:"chain_id"                 gencodec:"required"`
+	Nonce                uint64            `json:"nonce"                    gencodec:"required"`
+	GasLimit             uint64            `json:"gas_limit"                gencodec:"required"`
+	MaxFeePerGas         *big.Int          `json:"max_fee_per_gas"          gencodec:"required"`
+	MaxPriorityFeePerGas *big.Int          `json:"max_priority_fee_per_gas" gencodec:"required"`
+	To                   common.Address    `json:"to"                       gencodec:"required"`
+	Value                *big.Int          `json:"value"                    gencodec:"required"`
+	AccessList           AccessList        `json:"access_list"              gencodec:"required"`
+	AuthorizationList    AuthorizationList `json:"authorization_list"       gencodec:"required"`
+	Input                []byte            `json:"input"                    gencodec:"required"`
+}
+
+type txEip7702Marshaling struct {
+	ChainID              *Decimal256           `json:"chain_id"                 gencodec:"required"`
+	Nonce                Decimal64             `json:"nonce"                    gencodec:"required"`
+	GasLimit             Decimal64             `json:"gas_limit"                gencodec:"required"`
+	MaxFeePerGas         *Decimal256           `json:"max_fee_per_gas"          gencodec:"required"`
+	MaxPriorityFeePerGas *Decimal256           `json:"max_priority_fee_per_gas" gencodec:"required"`
 	Value                *math.HexOrDecimal256 `json:"value"                    gencodec:"required"`
-	MaxFeePerBlobGas     *math.HexOrDecimal256 `json:"max_fee_per_blob_gas"     gencodec:"required"`
 	Input                hexutil.Bytes         `json:"input"                    gencodec:"required"`
 }
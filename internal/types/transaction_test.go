@@ -0,0 +1,209 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// toRaikoJSON converts a signed geth transaction to the raiko format.
+func toRaikoJSON(t *testing.T, tx *types.Transaction) json.RawMessage {
+	v, r, s := tx.RawSignatureValues()
+	var inner map[string]any
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		inner = map[string]any{"Eip1559": &TxEip1559{
+			ChainID:              tx.ChainId(),
+			Nonce:                tx.Nonce(),
+			GasLimit:             tx.Gas(),
+			MaxFeePerGas:         tx.GasFeeCap(),
+			MaxPriorityFeePerGas: tx.GasTipCap(),
+			To:                   tx.To(),
+			Value:                tx.Value(),
+			AccessList:           NewAccessList(tx.AccessList()),
+			Input:                tx.Data(),
+		}}
+	case types.SetCodeTxType:
+		inner = map[string]any{"Eip7702": &TxEip7702{
+			ChainID:              tx.ChainId(),
+			Nonce:                tx.Nonce(),
+			GasLimit:             tx.Gas(),
+			MaxFeePerGas:         tx.GasFeeCap(),
+			MaxPriorityFeePerGas: tx.GasTipCap(),
+			To:                   *tx.To(),
+			Value:                tx.Value(),
+			AccessList:           NewAccessList(tx.AccessList()),
+			AuthorizationList:    NewAuthorizationList(tx.SetCodeAuthorizations()),
+			Input:                tx.Data(),
+		}}
+	default:
+		t.Fatalf("unsupported tx type: %d", tx.Type())
+	}
+	data, err := json.Marshal(map[string]any{
+		"hash": tx.Hash(),
+		"signature": &Signature{
+			R:          r,
+			S:          s,
+			OddYParity: v.Sign() != 0,
+		},
+		"transaction": inner,
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func TestTransactionSignedListEip7702(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	authority, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(167000)
+	signer := types.NewPragueSigner(chainID)
+	delegate := common.HexToAddress("0x0000000000000000000000000000000000007702")
+
+	auth, err := types.SignSetCode(authority, types.SetCodeAuthorization{
+		ChainID: *uint256.MustFromBig(chainID),
+		Address: delegate,
+		Nonce:   3,
+	})
+	require.NoError(t, err)
+	accessList := types.AccessList{{
+		Address:     delegate,
+		StorageKeys: []common.Hash{common.HexToHash("0x01")},
+	}}
+	txs := []*types.Transaction{
+		types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     0,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(1_000_000_000),
+			Gas:       21_000,
+			To:        &delegate,
+			Value:     big.NewInt(1),
+		}),
+		types.MustSignNewTx(key, signer, &types.SetCodeTx{
+			ChainID:    uint256.MustFromBig(chainID),
+			Nonce:      1,
+			GasTipCap:  uint256.NewInt(1),
+			GasFeeCap:  uint256.NewInt(1_000_000_000),
+			Gas:        100_000,
+			To:         crypto.PubkeyToAddress(authority.PublicKey),
+			Value:      uint256.NewInt(0),
+			Data:       []byte{0xde, 0xad, 0xbe, 0xef},
+			AccessList: accessList,
+			AuthList:   []types.SetCodeAuthorization{auth},
+		}),
+	}
+
+	raw := make([]json.RawMessage, len(txs))
+	for i, tx := range txs {
+		raw[i] = toRaikoJSON(t, tx)
+	}
+	data, err := json.Marshal(raw)
+	require.NoError(t, err)
+
+	var list TransactionSignedList
+	require.NoError(t, json.Unmarshal(data, &list))
+	got := list.GethType()
+	require.Len(t, got, len(txs))
+	for i, tx := range txs {
+		assert.Equal(t, tx.Type(), got[i].Type())
+		assert.Equal(t, tx.Hash(), got[i].Hash())
+		assert.Equal(t, list[i].Hash, got[i].Hash())
+		want, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		have, err := got[i].MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, want, have)
+
+		sender, err := types.Sender(signer, got[i])
+		require.NoError(t, err)
+		assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), sender)
+	}
+
+	authList := got[1].SetCodeAuthorizations()
+	require.Len(t, authList, 1)
+	assert.Equal(t, auth, authList[0])
+	signer7702, err := authList[0].Authority()
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(authority.PublicKey), signer7702)
+}
+
+func TestSignedAuthorizationUnmarshal(t *testing.T) {
+	data := `{
+		"chain_id": "0x28c58",
+		"address": "0x0000000000000000000000000000000000007702",
+		"nonce": "0x3",
+		"y_parity": "0x1",
+		"r": "0x1",
+		"s": "0x2"
+	}`
+	var auth SignedAuthorization
+	require.NoError(t, json.Unmarshal([]byte(data), &auth))
+	gethAuth := auth.GethType()
+	assert.Equal(t, uint64(167000), gethAuth.ChainID.Uint64())
+	assert.Equal(t, uint64(3), gethAuth.Nonce)
+	assert.Equal(t, uint8(1), gethAuth.V)
+
+	var missing SignedAuthorization
+	assert.Error(t, json.Unmarshal([]byte(`{"chain_id": "0x1"}`), &missing))
+}
+
+func TestNewTransactionSigned(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(167000)
+	to := common.HexToAddress("0x0000000000000000000000000000000000001559")
+	txs := []*types.Transaction{
+		types.MustSignNewTx(key, types.HomesteadSigner{}, &types.LegacyTx{
+			Nonce:    0,
+			GasPrice: big.NewInt(1_000_000_000),
+			Gas:      21_000,
+			To:       &to,
+			Value:    big.NewInt(1),
+		}),
+		types.MustSignNewTx(key, types.NewEIP155Signer(chainID), &types.LegacyTx{
+			Nonce:    1,
+			GasPrice: big.NewInt(1_000_000_000),
+			Gas:      21_000,
+			To:       &to,
+			Value:    big.NewInt(1),
+		}),
+		types.MustSignNewTx(key, types.NewEIP2930Signer(chainID), &types.AccessListTx{
+			ChainID:  chainID,
+			Nonce:    2,
+			GasPrice: big.NewInt(1_000_000_000),
+			Gas:      30_000,
+			To:       &to,
+			AccessList: types.AccessList{{
+				Address:     to,
+				StorageKeys: []common.Hash{common.HexToHash("0x01")},
+			}},
+		}),
+		types.MustSignNewTx(key, types.NewLondonSigner(chainID), &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     3,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(1_000_000_000),
+			Gas:       21_000,
+			Data:      []byte{0x01, 0x02},
+		}),
+	}
+	for _, tx := range txs {
+		signed, err := NewTransactionSigned(tx)
+		require.NoError(t, err)
+		data, err := json.Marshal(signed)
+		require.NoError(t, err)
+		var dec TransactionSigned
+		require.NoError(t, json.Unmarshal(data, &dec))
+		assert.Equal(t, tx.Hash(), dec.Hash)
+		assert.Equal(t, tx.Hash(), dec.GethType().Hash())
+	}
+}
@@ -37,6 +37,53 @@ var (
 		"ITaikoInbox.Transition",
 		encoding.BatchTransitionComponents,
 	)
+	shastaProposalComponents = []abi.ArgumentMarshaling{
+		{Name: "id", Type: "uint48"},
+		{Name: "timestamp", Type: "uint48"},
+		{Name: "endOfSubmissionWindowTimestamp", Type: "uint48"},
+		{Name: "proposer", Type: "address"},
+		{Name: "coreStateHash", Type: "bytes32"},
+		{Name: "derivationHash", Type: "bytes32"},
+	}
+	shastaDerivationComponents = []abi.ArgumentMarshaling{
+		{Name: "originBlockNumber", Type: "uint48"},
+		{Name: "originBlockHash", Type: "bytes32"},
+		{Name: "isForcedInclusion", Type: "bool"},
+		{Name: "basefeeSharingPctg", Type: "uint8"},
+		{
+			Name: "blobSlice",
+			Type: "tuple",
+			Components: []abi.ArgumentMarshaling{
+				{Name: "blobHashes", Type: "bytes32[]"},
+				{Name: "offset", Type: "uint24"},
+				{Name: "timestamp", Type: "uint48"},
+			},
+		},
+	}
+	shastaTransitionComponents = []abi.ArgumentMarshaling{
+		{Name: "proposalHash", Type: "bytes32"},
+		{Name: "parentTransitionHash", Type: "bytes32"},
+		{
+			Name: "checkpoint",
+			Type: "tuple",
+			Components: []abi.ArgumentMarshaling{
+				{Name: "blockNumber", Type: "uint48"},
+				{Name: "blockHash", Type: "bytes32"},
+				{Name: "stateRoot", Type: "bytes32"},
+			},
+		},
+		{Name: "designatedProver", Type: "address"},
+		{Name: "actualProver", Type: "address"},
+	}
+	shastaProposalComponentsType, _   = abi.NewType("tuple", "IInbox.Proposal", shastaProposalComponents)
+	shastaDerivationComponentsType, _ = abi.NewType("tuple", "IInbox.Derivation", shastaDerivationComponents)
+	shastaTransitionComponentsType, _ = abi.NewType("tuple", "IInbox.Transition", shastaTransitionComponents)
+	shastaProposalComponentsArgs      = abi.Arguments{{Name: "_proposal", Type: shastaProposalComponentsType}}
+	shastaDerivationComponentsArgs    = abi.Arguments{{Name: "_derivation", Type: shastaDerivationComponentsType}}
+	shastaProposedArgs                = abi.Arguments{
+		{Name: "proposal", Type: shastaProposalComponentsType},
+		{Name: "derivation", Type: shastaDerivationComponentsType},
+	}
 	publicInputsV1Type = abi.Arguments{
 		{Name: "VERIFY_PROOF", Type: stringTy},
 		{Name: "_chainId", Type: uint64Ty},
@@ -54,6 +101,22 @@ var (
 		{Name: "_newInstance", Type: addressTy},
 		{Name: "_metaHash", Type: byte32Ty},
 	}
+	publicInputsV3Type = abi.Arguments{
+		{Name: "VERIFY_PROOF", Type: stringTy},
+		{Name: "_chainId", Type: uint64Ty},
+		{Name: "_verifierContract", Type: addressTy},
+		{Name: "_transition", Type: shastaTransitionComponentsType},
+		{Name: "_newInstance", Type: addressTy},
+	}
+	publicInputsL1Type = abi.Arguments{
+		{Name: "VERIFY_PROOF", Type: stringTy},
+		{Name: "_chainId", Type: uint64Ty},
+		{Name: "_verifierContract", Type: addressTy},
+		{Name: "_parentHash", Type: byte32Ty},
+		{Name: "_blockHash", Type: byte32Ty},
+		{Name: "_stateRoot", Type: byte32Ty},
+		{Name: "_newInstance", Type: addressTy},
+	}
 	batchTxHashArgs = abi.Arguments{
 		{Name: "_txListHash", Type: byte32Ty},
 		{Name: "blobHashes_", Type: byte32sTy},
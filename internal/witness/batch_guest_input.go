@@ -5,8 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"iter"
-	"math"
 	"math/big"
+	"reflect"
 	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -14,12 +14,13 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
-	"github.com/taikoxyz/gaiko/pkg/keccak"
-	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
 )
 
 var _ WitnessInput = (*BatchGuestInput)(nil)
+
+var errNoInputs = errors.New("no inputs")
 var _ json.Unmarshaler = (*BatchGuestInput)(nil)
+var _ json.Marshaler = (*BatchGuestInput)(nil)
 
 type BatchGuestInput struct {
 	Inputs []*GuestInput
@@ -42,6 +43,19 @@ type TaikoGuestBatchInput struct {
 func (g *BatchGuestInput) GuestInputs() iter.Seq[*Pair] {
 	return func(yield func(*Pair) bool) {
 		batchProposed := g.Taiko.BatchProposed
+		if proposed, ok := batchProposed.(*ShastaBlockProposed); ok {
+			// a Shasta proposal lists its blocks in the manifest of its blobs
+			blocks := shastaManifest(g, proposed).Blocks
+			for i := range min(len(blocks), len(g.Inputs)) {
+				_txs := []*types.Transaction{g.Inputs[i].Taiko.AnchorTx}
+				_txs = append(_txs, blocks[i].Transactions...)
+				if !yield(&Pair{g.Inputs[i], _txs}) {
+					return
+				}
+			}
+			return
+		}
+		blockParams := batchProposed.BlockParams()
 		var txs types.Transactions
 		if batchProposed.BlobUsed() {
 			var compressedTxListBuf []byte
@@ -80,7 +94,6 @@ func (g *BatchGuestInput) GuestInputs() iter.Seq[*Pair] {
 			)
 		}
 
-		blockParams := batchProposed.BlockParams()
 		start := 0
 		for i, blockParam := range blockParams {
 			numTxs := int(blockParam.NumTransactions)
@@ -99,17 +112,6 @@ func (g *BatchGuestInput) BlockProposedFork() BlockProposedFork {
 	return g.Taiko.BatchProposed
 }
 
-func (g *BatchGuestInput) calculatePacayaTxsHash(
-	txListHash common.Hash,
-	blobHashes [][32]byte,
-) (common.Hash, error) {
-	data, err := batchTxHashArgs.Pack(txListHash, blobHashes)
-	if err != nil {
-		return common.Hash{}, err
-	}
-	return keccak.Keccak(data), nil
-}
-
 func (g *BatchGuestInput) Verify(proofType ProofType) error {
 	// 1. verify chain spec
 	for input := range slices.Values(g.Inputs) {
@@ -159,7 +161,7 @@ func (g *BatchGuestInput) Verify(proofType ProofType) error {
 
 	// 4. verify inputs length
 	if len(g.Inputs) == 0 {
-		return errors.New("no inputs")
+		return errNoInputs
 	}
 	if len(g.Inputs) > maxBlocksPerBatch {
 		return fmt.Errorf(
@@ -202,88 +204,44 @@ func (g *BatchGuestInput) Verify(proofType ProofType) error {
 }
 
 func (g *BatchGuestInput) BlockMetadataFork() (BlockMetadataFork, error) {
-	txListHash := keccak.Keccak(g.Taiko.TxDataFromCalldata)
-	txsHash, err := g.calculatePacayaTxsHash(txListHash, g.Taiko.BatchProposed.BlobHashes())
-	if err != nil {
-		return nil, err
-	}
-
-	blocks := make([]pacaya.ITaikoInboxBlockParams, 0, len(g.Inputs))
-	parentTs := g.Inputs[0].Block.Time()
-
-	if len(g.Inputs) != len(g.Taiko.BatchProposed.BlockParams()) {
-		return nil, fmt.Errorf(
-			"mismatched inputs: %d and block parameters: %d length",
-			len(g.Inputs),
-			len(g.Taiko.BatchProposed.BlockParams()),
-		)
+	if len(g.Inputs) == 0 {
+		return nil, errNoInputs
 	}
-	for idx, input := range g.Inputs {
-		signalSlots, err := decodeAnchorV3Args_signalSlots(input.Taiko.AnchorTx.Data()[4:])
-		if err != nil {
-			return nil, err
-		}
-		if input.Block.Time() < parentTs || (input.Block.Time()-parentTs) > math.MaxUint8 {
+	// the blocks are executed with the chain config of their own input, as
+	// they are decoded, it must be the one of the batch
+	for _, input := range g.Inputs {
+		if !reflect.DeepEqual(input.ChainSpec, g.Taiko.ChainSpec) {
 			return nil, fmt.Errorf(
-				"invalid delta block time, parent: %d, current: %d",
-				parentTs,
-				input.Block.Time(),
+				"block %d chain spec %s doesn't match the batch chain spec %s",
+				input.Block.NumberU64(), input.ChainSpec.Name, g.Taiko.ChainSpec.Name,
 			)
 		}
-		blockParams := pacaya.ITaikoInboxBlockParams{
-			NumTransactions: g.Taiko.BatchProposed.BlockParams()[idx].NumTransactions,
-			TimeShift:       uint8(input.Block.Time() - parentTs),
-			SignalSlots:     signalSlots,
-		}
-		parentTs = input.Block.Time()
-		blocks = append(blocks, blockParams)
-	}
-
-	batchInfo := &pacaya.ITaikoInboxBatchInfo{
-		TxsHash:            txsHash,
-		Blocks:             blocks,
-		BlobHashes:         g.Taiko.BatchProposed.BlobHashes(),
-		ExtraData:          g.Taiko.BatchProposed.ExtraData(),
-		Coinbase:           g.Taiko.BatchProposed.Coinbase(),
-		ProposedIn:         g.Taiko.BatchProposed.ProposedIn(),
-		BlobByteOffset:     g.Taiko.BatchProposed.BlobTxListOffset(),
-		BlobByteSize:       g.Taiko.BatchProposed.BlobTxListLength(),
-		GasLimit:           g.Taiko.BatchProposed.GasLimit(),
-		LastBlockId:        g.Inputs[len(g.Inputs)-1].Block.NumberU64(),
-		LastBlockTimestamp: g.Inputs[len(g.Inputs)-1].Block.Time(),
-		AnchorBlockId:      g.Taiko.L1Header.Number.Uint64(),
-		AnchorBlockHash:    g.Taiko.L1Header.Hash(),
-		BaseFeeConfig:      *g.Taiko.BatchProposed.BaseFeeConfig(),
-		BlobCreatedIn:      g.Taiko.BatchProposed.BlobCreatedIn(),
 	}
-
-	data, err := batchInfoComponentsArgs.Pack(batchInfo)
+	fork, err := lookupBatchHardFork(g.Taiko.BatchProposed.HardFork())
 	if err != nil {
 		return nil, err
 	}
-	infoHash := keccak.Keccak(data)
-
-	return NewPacayaBlockMetadata(&pacaya.ITaikoInboxBatchMetadata{
-		InfoHash:   infoHash,
-		Proposer:   g.Taiko.BatchProposed.Proposer(),
-		BatchId:    g.Taiko.BatchID,
-		ProposedAt: g.Taiko.BatchProposed.ProposedAt(),
-	}), nil
-
+	return fork.metadata(g)
 }
 
-func (g *BatchGuestInput) Transition() any {
-	firstBlock := g.Inputs[0].Block
-	lastBlock := g.Inputs[len(g.Inputs)-1].Block
-	return &pacaya.ITaikoInboxTransition{
-		ParentHash: firstBlock.ParentHash(),
-		BlockHash:  lastBlock.Hash(),
-		StateRoot:  lastBlock.Root(),
+func (g *BatchGuestInput) Transition() (any, error) {
+	if len(g.Inputs) == 0 {
+		return nil, errNoInputs
 	}
+	fork, err := lookupBatchHardFork(g.Taiko.BatchProposed.HardFork())
+	if err != nil {
+		return nil, err
+	}
+	return fork.transition(g)
 }
 
 func (g *BatchGuestInput) ForkVerifierAddress(proofType ProofType) common.Address {
-	return g.Taiko.ChainSpec.getForkVerifierAddress(g.Taiko.BatchProposed.BlockNumber(), proofType)
+	blockNum := g.Taiko.BatchProposed.BlockNumber()
+	// Shasta proposals don't carry the number of their first block
+	if blockNum == 0 && len(g.Inputs) != 0 {
+		blockNum = g.Inputs[0].Block.NumberU64()
+	}
+	return g.Taiko.ChainSpec.getForkVerifierAddress(blockNum, proofType)
 }
 
 func (g *BatchGuestInput) Prover() common.Address {
@@ -1,36 +1,42 @@
 package witness
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/log"
 	gaikoTypes "github.com/taikoxyz/gaiko/internal/types"
+	"github.com/taikoxyz/gaiko/pkg/mpt"
+	"github.com/taikoxyz/gaiko/pkg/serde"
 )
 
 type batchGuestInputJSON struct {
-	Inputs []*guestInputJSON         `json:"inputs"`
-	Taiko  *taikoGuestBatchInputJSON `json:"taiko"`
+	SchemaVersion SchemaVersion             `json:"schema_version,omitempty"`
+	Inputs        []*guestInputJSON         `json:"inputs"`
+	Taiko         *taikoGuestBatchInputJSON `json:"taiko"`
 }
 
-func (g *batchGuestInputJSON) GethType() *BatchGuestInput {
-	if g == nil {
-		log.Warn("missing batchGuestInputJSON when converting to GethType")
-		return nil
-	}
-	inputs := make([]*GuestInput, len(g.Inputs))
-	res := &BatchGuestInput{
-		Inputs: inputs,
-		Taiko:  g.Taiko.GethType(),
-	}
+func newBatchGuestInputJSON(g *BatchGuestInput) (*batchGuestInputJSON, error) {
+	inputs := make([]*guestInputJSON, len(g.Inputs))
 	for i, input := range g.Inputs {
-		input := input.GethType()
-		if input != nil {
-			input.parent = res
+		enc, err := newGuestInputJSON(input)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
 		}
-		inputs[i] = input
+		// the version of the batch applies to its inputs
+		enc.SchemaVersion = 0
+		inputs[i] = enc
 	}
-	return res
+	return &batchGuestInputJSON{
+		SchemaVersion: CurrentSchemaVersion,
+		Inputs:        inputs,
+		Taiko:         newTaikoGuestBatchInputJSON(g.Taiko),
+	}, nil
 }
 
 type taikoGuestBatchInputJSON struct {
@@ -39,11 +45,11 @@ type taikoGuestBatchInputJSON struct {
 	BatchProposed      *blockProposedForkJSON  `json:"batch_proposed"`
 	ChainSpec          *ChainSpec              `json:"chain_spec"`
 	ProverData         *TaikoProverData        `json:"prover_data"`
-	TxDataFromCalldata []byte                  `json:"tx_data_from_calldata"`
+	TxDataFromCalldata serde.ByteList          `json:"tx_data_from_calldata"`
 	TxDataFromBlob     [][eth.BlobSize]byte    `json:"tx_data_from_blob"`
 	BlobCommitments    *[][commitmentSize]byte `json:"blob_commitments"`
 	BlobProofs         *[][proofSize]byte      `json:"blob_proofs"`
-	BlobProofType      BlobProofType           `json:"blob_proof_type"`
+	BlobProofType      BlobProofType           `json:"blob_proof_type" since:"2"`
 }
 
 func (t *taikoGuestBatchInputJSON) GethType() *TaikoGuestBatchInput {
@@ -65,11 +71,155 @@ func (t *taikoGuestBatchInputJSON) GethType() *TaikoGuestBatchInput {
 	}
 }
 
+func newTaikoGuestBatchInputJSON(t *TaikoGuestBatchInput) *taikoGuestBatchInputJSON {
+	if t == nil {
+		return nil
+	}
+	return &taikoGuestBatchInputJSON{
+		BatchID:            t.BatchID,
+		L1Header:           gaikoTypes.NewHeader(t.L1Header),
+		BatchProposed:      newBlockProposedForkJSON(t.BatchProposed),
+		ChainSpec:          t.ChainSpec,
+		ProverData:         t.ProverData,
+		TxDataFromCalldata: t.TxDataFromCalldata,
+		TxDataFromBlob:     t.TxDataFromBlob,
+		BlobCommitments:    t.BlobCommitments,
+		BlobProofs:         t.BlobProofs,
+		BlobProofType:      t.BlobProofType,
+	}
+}
+
 func (g *BatchGuestInput) UnmarshalJSON(data []byte) error {
-	var dec batchGuestInputJSON
-	if err := json.Unmarshal(data, &dec); err != nil {
+	return g.DecodeStream(bytes.NewReader(data))
+}
+
+// DecodeStream decodes the JSON of a batch from r. Each element of `inputs` is
+// converted as soon as it's read and its tries are interned into a pool shared
+// by the batch, so only the JSON of a single block is held at a time.
+func (g *BatchGuestInput) DecodeStream(r io.Reader) error {
+	return g.DecodeStreamFunc(r, nil)
+}
+
+// DecodeStreamFunc is DecodeStream calling fn with every input as soon as it's
+// decoded. The fields of the batch aren't set before DecodeStreamFunc returns,
+// so fn must only use the fields of the input itself.
+func (g *BatchGuestInput) DecodeStreamFunc(r io.Reader, fn func(*GuestInput) error) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	res := &BatchGuestInput{}
+	// without a version first the versions of the inputs and the taiko part are
+	// inferred, a version that comes after them can't apply anymore
+	var (
+		version SchemaVersion
+		decoded bool
+	)
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "schema_version":
+			if decoded {
+				return errors.New("schema_version must come before inputs and taiko")
+			}
+			if err := dec.Decode(&version); err != nil {
+				return err
+			}
+			if err := checkSchemaVersion(version); err != nil {
+				return err
+			}
+		case "inputs":
+			decoded = true
+			if res.Inputs, err = decodeInputs(dec, res, version, fn); err != nil {
+				return err
+			}
+		case "taiko":
+			decoded = true
+			var taiko *taikoGuestBatchInputJSON
+			if err := dec.Decode(&taiko); err != nil {
+				return err
+			}
+			if taiko != nil {
+				if err := taiko.migrate(version); err != nil {
+					return err
+				}
+			}
+			res.Taiko = taiko.GethType()
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
 		return err
 	}
-	*g = *dec.GethType()
+	*g = *res
 	return nil
 }
+
+func decodeInputs(dec *json.Decoder, parent *BatchGuestInput, version SchemaVersion, fn func(*GuestInput) error) ([]*GuestInput, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if tok != json.Delim('[') {
+		return nil, fmt.Errorf("expected inputs array, got %v", tok)
+	}
+	// the nodes that consecutive blocks have in common are only kept once
+	pool := mpt.NewPool()
+	inputs := []*GuestInput{}
+	for dec.More() {
+		var input *guestInputJSON
+		if err := dec.Decode(&input); err != nil {
+			return nil, err
+		}
+		var res *GuestInput
+		if input != nil {
+			if err := input.migrate(cmp.Or(input.SchemaVersion, version)); err != nil {
+				return nil, err
+			}
+			if err := input.intern(pool); err != nil {
+				return nil, err
+			}
+			res = input.GethType()
+			res.parent = parent
+			if fn != nil {
+				if err := fn(res); err != nil {
+					return nil, err
+				}
+			}
+		}
+		inputs = append(inputs, res)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+	return nil
+}
+
+func (g *BatchGuestInput) MarshalJSON() ([]byte, error) {
+	enc, err := newBatchGuestInputJSON(g)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(enc)
+}
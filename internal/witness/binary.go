@@ -0,0 +1,320 @@
+package witness
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/taikoxyz/gaiko/pkg/mpt"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*GuestInput)(nil)
+	_ encoding.BinaryUnmarshaler = (*GuestInput)(nil)
+	_ encoding.BinaryMarshaler   = (*BatchGuestInput)(nil)
+	_ encoding.BinaryUnmarshaler = (*BatchGuestInput)(nil)
+)
+
+// binaryVersion prefixes the binary encoding of the witnesses.
+//
+// The binary encoding is gaiko's own, it isn't the serialization raiko uses
+// for its zk guests and raiko doesn't produce it. It is the RLP of the
+// structures below: blocks, headers and transactions use their consensus
+// encoding and tries the nested encoding of mpt.MptNode.MarshalBinary. The
+// small fork specific parts keep their JSON form.
+const binaryVersion = 1
+
+type guestInputRLP struct {
+	Block            *types.Block
+	ChainSpec        []byte
+	ParentHeader     *types.Header `rlp:"nil"`
+	ParentStateTrie  []byte
+	ParentStorage    []*storageEntryRLP
+	Contracts        [][]byte
+	AncestorHeaders  []*types.Header
+	Taiko            *taikoGuestInputRLP `rlp:"nil"`
+	ExecutionWitness *ExecutionWitness   `rlp:"optional"`
+}
+
+type storageEntryRLP struct {
+	Address common.Address
+	Trie    []byte
+	Slots   []*big.Int
+}
+
+type taikoGuestInputRLP struct {
+	L1Header       *types.Header `rlp:"nil"`
+	TxData         []byte
+	AnchorTx       []byte
+	BlockProposed  []byte
+	ProverData     *TaikoProverData      `rlp:"nil"`
+	BlobCommitment *[commitmentSize]byte `rlp:"nil"`
+	BlobProof      *[proofSize]byte      `rlp:"nil"`
+	BlobProofType  BlobProofType
+}
+
+type batchGuestInputRLP struct {
+	Inputs []*guestInputRLP
+	Taiko  *taikoGuestBatchInputRLP `rlp:"nil"`
+}
+
+type taikoGuestBatchInputRLP struct {
+	BatchID            uint64
+	L1Header           *types.Header `rlp:"nil"`
+	BatchProposed      []byte
+	ChainSpec          []byte
+	ProverData         *TaikoProverData `rlp:"nil"`
+	TxDataFromCalldata []byte
+	TxDataFromBlob     [][eth.BlobSize]byte
+	BlobCommitments    *[][commitmentSize]byte `rlp:"nil"`
+	BlobProofs         *[][proofSize]byte      `rlp:"nil"`
+	BlobProofType      BlobProofType
+}
+
+func (g *GuestInput) MarshalBinary() ([]byte, error) {
+	enc, err := newGuestInputRLP(g)
+	if err != nil {
+		return nil, err
+	}
+	return marshalBinary(enc)
+}
+
+func (g *GuestInput) UnmarshalBinary(data []byte) error {
+	var dec guestInputRLP
+	if err := unmarshalBinary(data, &dec); err != nil {
+		return err
+	}
+	res, err := dec.gethType(nil)
+	if err != nil {
+		return err
+	}
+	*g = *res
+	return nil
+}
+
+func (g *BatchGuestInput) MarshalBinary() ([]byte, error) {
+	enc := &batchGuestInputRLP{Inputs: make([]*guestInputRLP, len(g.Inputs))}
+	for i, input := range g.Inputs {
+		var err error
+		if enc.Inputs[i], err = newGuestInputRLP(input); err != nil {
+			return nil, err
+		}
+	}
+	if t := g.Taiko; t != nil {
+		batchProposed, err := json.Marshal(newBlockProposedForkJSON(t.BatchProposed))
+		if err != nil {
+			return nil, err
+		}
+		chainSpec, err := json.Marshal(t.ChainSpec)
+		if err != nil {
+			return nil, err
+		}
+		enc.Taiko = &taikoGuestBatchInputRLP{
+			BatchID:            t.BatchID,
+			L1Header:           t.L1Header,
+			BatchProposed:      batchProposed,
+			ChainSpec:          chainSpec,
+			ProverData:         t.ProverData,
+			TxDataFromCalldata: t.TxDataFromCalldata,
+			TxDataFromBlob:     t.TxDataFromBlob,
+			BlobCommitments:    t.BlobCommitments,
+			BlobProofs:         t.BlobProofs,
+			BlobProofType:      t.BlobProofType,
+		}
+	}
+	return marshalBinary(enc)
+}
+
+func (g *BatchGuestInput) UnmarshalBinary(data []byte) error {
+	var dec batchGuestInputRLP
+	if err := unmarshalBinary(data, &dec); err != nil {
+		return err
+	}
+	res := &BatchGuestInput{Inputs: make([]*GuestInput, len(dec.Inputs))}
+	// share the trie nodes of the blocks like the JSON decoding does
+	pool := mpt.NewPool()
+	for i, input := range dec.Inputs {
+		var err error
+		if res.Inputs[i], err = input.gethType(pool); err != nil {
+			return fmt.Errorf("inputs[%d]: %w", i, err)
+		}
+		res.Inputs[i].parent = res
+	}
+	if t := dec.Taiko; t != nil {
+		var batchProposed blockProposedForkJSON
+		if err := json.Unmarshal(t.BatchProposed, &batchProposed); err != nil {
+			return err
+		}
+		var chainSpec *ChainSpec
+		if err := json.Unmarshal(t.ChainSpec, &chainSpec); err != nil {
+			return err
+		}
+		res.Taiko = &TaikoGuestBatchInput{
+			BatchID:            t.BatchID,
+			L1Header:           t.L1Header,
+			BatchProposed:      batchProposed.GethType(),
+			ChainSpec:          chainSpec,
+			ProverData:         t.ProverData,
+			TxDataFromCalldata: t.TxDataFromCalldata,
+			TxDataFromBlob:     t.TxDataFromBlob,
+			BlobCommitments:    t.BlobCommitments,
+			BlobProofs:         t.BlobProofs,
+			BlobProofType:      t.BlobProofType,
+		}
+	}
+	*g = *res
+	return nil
+}
+
+func marshalBinary(val any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(binaryVersion)
+	if err := rlp.Encode(&buf, val); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshalBinary(data []byte, val any) error {
+	if len(data) == 0 {
+		return errors.New("empty binary witness")
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("unsupported binary witness version: %d", data[0])
+	}
+	return rlp.DecodeBytes(data[1:], val)
+}
+
+func newGuestInputRLP(g *GuestInput) (*guestInputRLP, error) {
+	chainSpec, err := json.Marshal(g.ChainSpec)
+	if err != nil {
+		return nil, err
+	}
+	enc := &guestInputRLP{
+		Block:            g.Block,
+		ChainSpec:        chainSpec,
+		ParentHeader:     g.ParentHeader,
+		Contracts:        g.Contracts,
+		AncestorHeaders:  g.AncestorHeaders,
+		ExecutionWitness: g.ExecutionWitness,
+	}
+	if enc.ParentStateTrie, err = marshalTrie(g.ParentStateTrie); err != nil {
+		return nil, err
+	}
+	// sort the storage entries so that the encoding is deterministic
+	addrs := slices.SortedFunc(maps.Keys(g.ParentStorage), common.Address.Cmp)
+	for _, addr := range addrs {
+		entry := g.ParentStorage[addr]
+		trie, err := marshalTrie(entry.Trie)
+		if err != nil {
+			return nil, err
+		}
+		enc.ParentStorage = append(enc.ParentStorage, &storageEntryRLP{addr, trie, entry.Slots})
+	}
+	if t := g.Taiko; t != nil {
+		enc.Taiko = &taikoGuestInputRLP{
+			L1Header:       t.L1Header,
+			TxData:         t.TxData,
+			ProverData:     t.ProverData,
+			BlobCommitment: t.BlobCommitment,
+			BlobProof:      t.BlobProof,
+			BlobProofType:  t.BlobProofType,
+		}
+		if t.AnchorTx != nil {
+			if enc.Taiko.AnchorTx, err = t.AnchorTx.MarshalBinary(); err != nil {
+				return nil, err
+			}
+		}
+		if t.BlockProposed != nil {
+			if enc.Taiko.BlockProposed, err = json.Marshal(newBlockProposedForkJSON(t.BlockProposed)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return enc, nil
+}
+
+// gethType converts the decoded input, its tries are interned into pool if it
+// isn't nil.
+func (g *guestInputRLP) gethType(pool *mpt.Pool) (*GuestInput, error) {
+	var chainSpec *ChainSpec
+	if err := json.Unmarshal(g.ChainSpec, &chainSpec); err != nil {
+		return nil, err
+	}
+	res := &GuestInput{
+		Block:            g.Block,
+		ChainSpec:        chainSpec,
+		ParentHeader:     g.ParentHeader,
+		ParentStorage:    make(map[common.Address]*StorageEntry, len(g.ParentStorage)),
+		Contracts:        g.Contracts,
+		AncestorHeaders:  g.AncestorHeaders,
+		ExecutionWitness: g.ExecutionWitness,
+	}
+	var err error
+	if res.ParentStateTrie, err = unmarshalTrie(g.ParentStateTrie, pool); err != nil {
+		return nil, err
+	}
+	for _, entry := range g.ParentStorage {
+		trie, err := unmarshalTrie(entry.Trie, pool)
+		if err != nil {
+			return nil, err
+		}
+		res.ParentStorage[entry.Address] = &StorageEntry{Trie: trie, Slots: entry.Slots}
+	}
+	if t := g.Taiko; t != nil {
+		res.Taiko = &TaikoGuestInput{
+			L1Header:       t.L1Header,
+			TxData:         t.TxData,
+			ProverData:     t.ProverData,
+			BlobCommitment: t.BlobCommitment,
+			BlobProof:      t.BlobProof,
+			BlobProofType:  t.BlobProofType,
+		}
+		if len(t.AnchorTx) != 0 {
+			res.Taiko.AnchorTx = new(types.Transaction)
+			if err := res.Taiko.AnchorTx.UnmarshalBinary(t.AnchorTx); err != nil {
+				return nil, err
+			}
+		}
+		if len(t.BlockProposed) != 0 {
+			var blockProposed blockProposedForkJSON
+			if err := json.Unmarshal(t.BlockProposed, &blockProposed); err != nil {
+				return nil, err
+			}
+			res.Taiko.BlockProposed = blockProposed.GethType()
+		}
+	}
+	return res, nil
+}
+
+func marshalTrie(trie *mpt.MptNode) ([]byte, error) {
+	if trie == nil {
+		return nil, nil
+	}
+	return trie.MarshalBinary()
+}
+
+func unmarshalTrie(data []byte, pool *mpt.Pool) (*mpt.MptNode, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	trie := new(mpt.MptNode)
+	if err := trie.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	if pool != nil {
+		if err := pool.Intern(trie); err != nil {
+			return nil, err
+		}
+	}
+	return trie, nil
+}
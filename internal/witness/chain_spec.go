@@ -29,11 +29,26 @@ func init() {
 	}
 }
 
+// LookupChainSpec returns a copy of the supported chain spec of a network.
+func LookupChainSpec(name Network) (*ChainSpec, error) {
+	for chainSpec := range slices.Values(defaultSupportedChainSpecs) {
+		if chainSpec.Name == name {
+			spec := *chainSpec
+			return &spec, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported network: %s", name)
+}
+
 func (s SupportedChainSpecs) verifyChainSpec(other *ChainSpec) error {
 	for chainSpec := range slices.Values(s) {
 		if chainSpec.ChainID != other.ChainID {
 			continue
 		}
+		// the name selects the chain config the blocks are executed with
+		if chainSpec.Name != other.Name {
+			return errors.New("unexpected name")
+		}
 		if chainSpec.MaxSpecID != other.MaxSpecID {
 			return errors.New("unexpected max_spec_id")
 		}
@@ -88,6 +103,9 @@ const (
 	SGXGethProofType ProofType = "SGXGETH"
 )
 
+// ShastaSpecID is the spec of the Shasta fork in the chain specs.
+const ShastaSpecID SpecID = "SHASTA"
+
 type HardFork struct {
 	SpecID    SpecID
 	Condition ForkCondition
@@ -169,8 +187,36 @@ func (h *HardForks) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (h HardForks) MarshalJSON() ([]byte, error) {
+	orderedMap := ordered.NewOrderedMap()
+	for _, fork := range h {
+		switch cond := fork.Condition.(type) {
+		case BlockNumber:
+			orderedMap.Set(string(fork.SpecID), map[string]uint64{"Block": uint64(cond)})
+		case BlockTimestamp:
+			orderedMap.Set(string(fork.SpecID), map[string]uint64{"Timestamp": uint64(cond)})
+		case TBD:
+			orderedMap.Set(string(fork.SpecID), "TBD")
+		default:
+			return nil, fmt.Errorf("unsupported type for hardfork: %T", cond)
+		}
+	}
+	return json.Marshal(orderedMap)
+}
+
 type VerifierAddressFork map[ProofType]*common.Address
 
+// raikoVerifierNames are the names raiko gives the verifiers of a fork, the
+// keys are upper-cased when decoded.
+var raikoVerifierNames = map[ProofType]string{
+	NativeProofType:  "Native",
+	Sp1ProofType:     "Sp1",
+	SGXProofType:     "Sgx",
+	Risc0ProofType:   "Risc0",
+	SGXGethProofType: "SgxGeth",
+	"PIVOT":          "Pivot",
+}
+
 func (vf *VerifierAddressFork) UnmarshalJSON(data []byte) error {
 	*vf = make(VerifierAddressFork)
 	var m map[string]*common.Address
@@ -183,12 +229,26 @@ func (vf *VerifierAddressFork) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (vf VerifierAddressFork) MarshalJSON() ([]byte, error) {
+	m := make(map[string]*common.Address, len(vf))
+	for k, v := range vf {
+		name, ok := raikoVerifierNames[k]
+		if !ok {
+			name = string(k)
+		}
+		m[name] = v
+	}
+	return json.Marshal(m)
+}
+
 type Network string
 
 const (
 	TaikoMainnetNetwork Network = "taiko_mainnet"
 	EthereumNetwork     Network = "ethereum"
 	HoleskyNetwork      Network = "holesky"
+	SepoliaNetwork      Network = "sepolia"
+	HoodiNetwork        Network = "hoodi"
 	TaikoDevNetwork     Network = "taiko_dev"
 	PreconfDevNetwork   Network = "preconf_dev"
 	MasayaDevNetwork    Network = "masaya_dev"
@@ -214,6 +274,17 @@ type ChainSpec struct {
 
 var _ json.Unmarshaler = (*ChainSpec)(nil)
 
+// ActiveSpecID returns the latest spec active at an L2 block, or "" before
+// the first one.
+func (c *ChainSpec) ActiveSpecID(blockNum uint64) SpecID {
+	for _, fork := range slices.Backward(c.HardForks) {
+		if fork.Condition.Active(blockNum, 0) {
+			return fork.SpecID
+		}
+	}
+	return ""
+}
+
 func (c *ChainSpec) getForkVerifierAddress(
 	blockNum uint64,
 	proofType ProofType,
@@ -268,6 +339,10 @@ func (c *ChainSpec) chainConfig() (*params.ChainConfig, error) {
 		return params.MainnetChainConfig, nil
 	case HoleskyNetwork:
 		return params.HoleskyChainConfig, nil
+	case SepoliaNetwork:
+		return params.SepoliaChainConfig, nil
+	case HoodiNetwork:
+		return params.HoodiChainConfig, nil
 	default:
 		return nil, fmt.Errorf("unsupported chain spec: %s", c.Name)
 	}
@@ -0,0 +1,235 @@
+package witness
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/gaiko/pkg/mpt"
+)
+
+// Difference is a difference between two witnesses found by Diff.
+type Difference struct {
+	// Path locates the difference by the JSON field names of the witness,
+	// e.g. `inputs[1].parent_storage[0x...].trie`.
+	Path string
+	Desc string
+}
+
+func (d *Difference) String() string {
+	return fmt.Sprintf("%s: %s", d.Path, d.Desc)
+}
+
+// Diff compares the state tries, storage entries, contracts, headers and
+// execution witnesses of two witnesses of the same kind.
+func Diff(a, b WitnessInput) ([]*Difference, error) {
+	d := &witnessDiffer{}
+	switch a := a.(type) {
+	case *GuestInput:
+		b, ok := b.(*GuestInput)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %T with %T", a, b)
+		}
+		if err := d.guestInput("", a, b); err != nil {
+			return nil, err
+		}
+	case *BatchGuestInput:
+		b, ok := b.(*BatchGuestInput)
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %T with %T", a, b)
+		}
+		if len(a.Inputs) != len(b.Inputs) {
+			d.add("inputs", "length %d -> %d", len(a.Inputs), len(b.Inputs))
+		}
+		for i := range min(len(a.Inputs), len(b.Inputs)) {
+			if err := d.guestInput(fmt.Sprintf("inputs[%d].", i), a.Inputs[i], b.Inputs[i]); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported witness type: %T", a)
+	}
+	return d.res, nil
+}
+
+type witnessDiffer struct {
+	res []*Difference
+}
+
+func (d *witnessDiffer) add(path, format string, args ...any) {
+	d.res = append(d.res, &Difference{Path: path, Desc: fmt.Sprintf(format, args...)})
+}
+
+func (d *witnessDiffer) guestInput(prefix string, a, b *GuestInput) error {
+	d.header(prefix+"parent_header", a.ParentHeader, b.ParentHeader)
+	d.headers(prefix+"ancestor_headers", a.AncestorHeaders, b.AncestorHeaders)
+	d.header(prefix+"block", blockHeader(a.Block), blockHeader(b.Block))
+	if err := d.trie(prefix+"parent_state_trie", a.ParentStateTrie, b.ParentStateTrie); err != nil {
+		return err
+	}
+	if err := d.storage(prefix+"parent_storage", a.ParentStorage, b.ParentStorage); err != nil {
+		return err
+	}
+	d.hashes(prefix+"contracts", keccakHashes(a.Contracts), keccakHashes(b.Contracts))
+	d.executionWitness(prefix+"execution_witness", a.ExecutionWitness, b.ExecutionWitness)
+	return nil
+}
+
+// executionWitness compares the trie nodes, codes and headers of two execution
+// witnesses as sets, geth doesn't keep them in any particular order.
+func (d *witnessDiffer) executionWitness(path string, a, b *ExecutionWitness) {
+	switch {
+	case a == nil && b == nil:
+		return
+	case a == nil:
+		d.add(path, "added with %d nodes", len(b.State))
+		return
+	case b == nil:
+		d.add(path, "removed with %d nodes", len(a.State))
+		return
+	}
+	d.hashes(path+".state", keccakHashes(a.State), keccakHashes(b.State))
+	d.hashes(path+".codes", keccakHashes(a.Codes), keccakHashes(b.Codes))
+	d.hashes(path+".headers", headerHashes(a.Headers), headerHashes(b.Headers))
+}
+
+func (d *witnessDiffer) header(path string, a, b *types.Header) {
+	switch {
+	case a == nil && b == nil:
+	case a == nil:
+		d.add(path, "added %#x", b.Hash())
+	case b == nil:
+		d.add(path, "removed %#x", a.Hash())
+	case a.Hash() != b.Hash():
+		d.add(path, "changed %#x -> %#x", a.Hash(), b.Hash())
+	}
+}
+
+func (d *witnessDiffer) headers(path string, a, b []*types.Header) {
+	for i := range max(len(a), len(b)) {
+		var headerA, headerB *types.Header
+		if i < len(a) {
+			headerA = a[i]
+		}
+		if i < len(b) {
+			headerB = b[i]
+		}
+		d.header(fmt.Sprintf("%s[%d]", path, i), headerA, headerB)
+	}
+}
+
+func (d *witnessDiffer) trie(path string, a, b *mpt.MptNode) error {
+	diffs, err := mpt.Diff(a, b)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, diff := range diffs {
+		d.add(path, "%s", diff)
+	}
+	return nil
+}
+
+func (d *witnessDiffer) storage(path string, a, b map[common.Address]*StorageEntry) error {
+	addrs := make([]common.Address, 0, len(a)+len(b))
+	for addr := range a {
+		addrs = append(addrs, addr)
+	}
+	for addr := range b {
+		if _, ok := a[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	slices.SortFunc(addrs, func(x, y common.Address) int {
+		return bytes.Compare(x[:], y[:])
+	})
+	for _, addr := range addrs {
+		entryPath := fmt.Sprintf("%s[%s]", path, addr.Hex())
+		entryA, entryB := a[addr], b[addr]
+		switch {
+		case entryA == nil:
+			d.add(entryPath, "added with %d slots", len(entryB.Slots))
+			continue
+		case entryB == nil:
+			d.add(entryPath, "removed with %d slots", len(entryA.Slots))
+			continue
+		}
+		if err := d.trie(entryPath+".trie", entryA.Trie, entryB.Trie); err != nil {
+			return err
+		}
+		d.slots(entryPath+".slots", entryA.Slots, entryB.Slots)
+	}
+	return nil
+}
+
+func (d *witnessDiffer) slots(path string, a, b []*big.Int) {
+	contains := func(slots []*big.Int, slot *big.Int) bool {
+		return slices.ContainsFunc(slots, func(s *big.Int) bool {
+			return s.Cmp(slot) == 0
+		})
+	}
+	for _, slot := range a {
+		if !contains(b, slot) {
+			d.add(path, "removed %#x", slot)
+		}
+	}
+	for _, slot := range b {
+		if !contains(a, slot) {
+			d.add(path, "added %#x", slot)
+		}
+	}
+}
+
+// hashes reports the hashes of a missing from b as removed and the hashes of b
+// missing from a as added.
+func (d *witnessDiffer) hashes(path string, a, b []common.Hash) {
+	set := func(hashes []common.Hash) map[common.Hash]struct{} {
+		res := make(map[common.Hash]struct{}, len(hashes))
+		for _, hash := range hashes {
+			res[hash] = struct{}{}
+		}
+		return res
+	}
+	setA, setB := set(a), set(b)
+	for _, hash := range a {
+		if !containsHash(setB, hash) {
+			d.add(path, "removed %#x", hash)
+		}
+	}
+	for _, hash := range b {
+		if !containsHash(setA, hash) {
+			d.add(path, "added %#x", hash)
+		}
+	}
+}
+
+func keccakHashes[T ~[]byte](data []T) []common.Hash {
+	res := make([]common.Hash, 0, len(data))
+	for _, b := range data {
+		res = append(res, keccak.Keccak(b))
+	}
+	return res
+}
+
+func headerHashes(headers []*types.Header) []common.Hash {
+	res := make([]common.Hash, 0, len(headers))
+	for _, header := range headers {
+		res = append(res, header.Hash())
+	}
+	return res
+}
+
+func containsHash(set map[common.Hash]struct{}, hash common.Hash) bool {
+	_, ok := set[hash]
+	return ok
+}
+
+func blockHeader(block *types.Block) *types.Header {
+	if block == nil {
+		return nil
+	}
+	return block.Header()
+}
@@ -0,0 +1,54 @@
+package witness
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/stateless"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+)
+
+// ExecutionWitness is the flat witness returned by geth's
+// `debug_executionWitness`: the trie nodes and codes touched by the block and
+// the headers from the parent backwards.
+type ExecutionWitness struct {
+	Headers []*types.Header `json:"headers"`
+	Codes   []hexutil.Bytes `json:"codes"`
+	State   []hexutil.Bytes `json:"state"`
+	// Keys are the preimages of the hashed keys, they aren't needed to execute.
+	Keys []hexutil.Bytes `json:"keys,omitempty"`
+}
+
+// newWitness converts the flat witness, it checks that it starts at the
+// parent header and carries the parent state root.
+func (e *ExecutionWitness) newWitness(parent *types.Header) (*stateless.Witness, error) {
+	if len(e.Headers) == 0 {
+		return nil, errors.New("execution witness without headers")
+	}
+	if parent != nil && e.Headers[0].Hash() != parent.Hash() {
+		return nil, fmt.Errorf("execution witness parent mismatch: expected %#x, got %#x",
+			parent.Hash(), e.Headers[0].Hash())
+	}
+	root := e.Headers[0].Root
+	wit := &stateless.Witness{
+		Headers: e.Headers,
+		Codes:   make(map[string]struct{}, len(e.Codes)),
+		State:   make(map[string]struct{}, len(e.State)),
+	}
+	for _, code := range e.Codes {
+		wit.Codes[string(code)] = struct{}{}
+	}
+	found := root == types.EmptyRootHash
+	for _, node := range e.State {
+		wit.State[string(node)] = struct{}{}
+		if !found && keccak.Keccak(node) == root {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("execution witness misses the parent state root %#x", root)
+	}
+	return wit, nil
+}
@@ -0,0 +1,196 @@
+package witness
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	gaikoTypes "github.com/taikoxyz/gaiko/internal/types"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+)
+
+func init() {
+	registerHardFork(&hardFork{
+		name: HeklaHardFork,
+		decodeProposed: func(data []byte) (BlockProposedFork, error) {
+			var inner gaikoTypes.BlockProposed
+			if err := json.Unmarshal(data, &inner); err != nil {
+				return nil, err
+			}
+			return NewHeklaBlockProposed(inner.GethType()), nil
+		},
+		proposedType: reflect.TypeFor[gaikoTypes.BlockProposed](),
+		encodeProposed: func(b BlockProposedFork) (any, error) {
+			proposed, ok := b.(*HeklaBlockProposed)
+			if !ok {
+				return nil, fmt.Errorf("unexpected proposal type for %s: %T", HeklaHardFork, b)
+			}
+			return gaikoTypes.NewBlockProposed(proposed.TaikoL1ClientBlockProposed), nil
+		},
+		block: &forkProving[*GuestInput]{
+			metadata:   heklaBlockMetadata,
+			transition: ontakeTransition,
+		},
+		encodePublicInput: encodeOntakePublicInput,
+	})
+}
+
+type HeklaBlockProposed struct {
+	*ontake.TaikoL1ClientBlockProposed
+}
+
+var _ BlockProposedFork = (*HeklaBlockProposed)(nil)
+
+func NewHeklaBlockProposed(b *ontake.TaikoL1ClientBlockProposed) *HeklaBlockProposed {
+	return &HeklaBlockProposed{b}
+}
+
+func (b *HeklaBlockProposed) ABIEncode() ([]byte, error) {
+	return blockMetadataComponentsArgs.Pack(
+		b.BlockId,
+		b.AssignedProver,
+		b.TaikoL1ClientBlockProposed.LivenessBond,
+		b.Meta,
+		b.DepositsProcessed,
+	)
+}
+
+func (b *HeklaBlockProposed) BlockNumber() uint64 {
+	return b.Meta.Id
+}
+
+func (b *HeklaBlockProposed) BlockTimestamp() uint64 {
+	return b.Meta.Timestamp
+}
+
+func (b *HeklaBlockProposed) BaseFeeConfig() *pacaya.LibSharedDataBaseFeeConfig {
+	return nil
+}
+
+func (b *HeklaBlockProposed) BlobTxSliceParam() *Slice {
+	return nil
+}
+
+func (b *HeklaBlockProposed) BlobUsed() bool {
+	return b.Meta.BlobUsed
+}
+
+func (b *HeklaBlockProposed) HardFork() string {
+	return HeklaHardFork
+}
+
+func (b *HeklaBlockProposed) MinTier() uint16 {
+	return b.Meta.MinTier
+}
+
+func (b *HeklaBlockProposed) ParentMetaHash() [32]byte {
+	return b.Meta.ParentMetaHash
+}
+
+func (b *HeklaBlockProposed) Sender() common.Address {
+	return b.Meta.Sender
+}
+
+func (b *HeklaBlockProposed) Difficulty() [32]byte {
+	return b.Meta.Difficulty
+}
+
+func (b *HeklaBlockProposed) Proposer() common.Address {
+	return common.Address{}
+}
+
+func (b *HeklaBlockProposed) LivenessBond() *big.Int {
+	return b.TaikoL1ClientBlockProposed.LivenessBond
+}
+
+func (b *HeklaBlockProposed) ProposedAt() uint64 {
+	return 0
+}
+
+func (b *HeklaBlockProposed) ProposedIn() uint64 {
+	return 0
+}
+
+func (b *HeklaBlockProposed) BlobTxListOffset() uint32 {
+	return 0
+}
+
+func (b *HeklaBlockProposed) BlobTxListLength() uint32 {
+	return 0
+}
+
+func (b *HeklaBlockProposed) BlobIndex() uint8 {
+	return 0
+}
+
+func (b *HeklaBlockProposed) GasLimit() uint32 {
+	return b.Meta.GasLimit
+}
+
+func (b *HeklaBlockProposed) Coinbase() common.Address {
+	return b.Meta.Coinbase
+}
+
+func (b *HeklaBlockProposed) BlobHashes() [][32]byte {
+	return nil
+}
+
+func (b *HeklaBlockProposed) ExtraData() [32]byte {
+	return b.Meta.ExtraData
+}
+
+func (b *HeklaBlockProposed) BlockParams() []*pacaya.ITaikoInboxBlockParams {
+	return nil
+}
+
+func (b *HeklaBlockProposed) BlobCreatedIn() uint64 {
+	return 0
+}
+
+func (b *HeklaBlockProposed) BlockMetadataFork() BlockMetadataFork {
+	return NewHeklaBlockMetadata(&b.Meta)
+}
+
+type HeklaBlockMetadata struct {
+	*ontake.TaikoDataBlockMetadata
+}
+
+func NewHeklaBlockMetadata(meta *ontake.TaikoDataBlockMetadata) *HeklaBlockMetadata {
+	return &HeklaBlockMetadata{meta}
+}
+
+func (m *HeklaBlockMetadata) ABIEncode() ([]byte, error) {
+	return blockMetadataComponentsArgs.Pack(m.TaikoDataBlockMetadata)
+}
+
+func (m *HeklaBlockMetadata) Hash() common.Hash {
+	b, _ := m.ABIEncode()
+	return keccak.Keccak(b)
+}
+
+func heklaBlockMetadata(g *GuestInput) (BlockMetadataFork, error) {
+	txListHash, err := g.txListHash()
+	if err != nil {
+		return nil, err
+	}
+	return NewHeklaBlockMetadata(&ontake.TaikoDataBlockMetadata{
+		L1Hash:         g.Taiko.L1Header.Hash(),
+		Difficulty:     g.Taiko.BlockProposed.Difficulty(),
+		BlobHash:       txListHash,
+		ExtraData:      g.extraData(),
+		DepositsHash:   emptyEthDepositHash,
+		Coinbase:       g.Block.Coinbase(),
+		Id:             g.Block.NumberU64(),
+		GasLimit:       g.proposedGasLimit(),
+		Timestamp:      g.Block.Time(),
+		L1Height:       g.Taiko.L1Header.Number.Uint64(),
+		MinTier:        g.Taiko.BlockProposed.MinTier(),
+		BlobUsed:       g.Taiko.BlockProposed.BlobUsed(),
+		ParentMetaHash: g.Taiko.BlockProposed.ParentMetaHash(),
+		Sender:         g.Taiko.BlockProposed.Sender(),
+	}), nil
+}
@@ -0,0 +1,64 @@
+package witness
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func init() {
+	registerHardFork(&hardFork{
+		name: L1HardFork,
+		block: &forkProving[*GuestInput]{
+			metadata: func(_ *GuestInput) (BlockMetadataFork, error) {
+				return &NothingBlockMetadata{}, nil
+			},
+			transition: l1Transition,
+		},
+		encodePublicInput: encodeL1PublicInput,
+	})
+}
+
+// L1BlockProposed marks an Ethereum block, there is no proposal on L1.
+type L1BlockProposed struct {
+	NotingBlockProposed
+}
+
+var _ BlockProposedFork = (*L1BlockProposed)(nil)
+
+func (b *L1BlockProposed) HardFork() string {
+	return L1HardFork
+}
+
+// L1BlockTransition is the transition of an Ethereum block.
+type L1BlockTransition struct {
+	ParentHash common.Hash
+	BlockHash  common.Hash
+	StateRoot  common.Hash
+}
+
+func l1Transition(g *GuestInput) (any, error) {
+	return &L1BlockTransition{
+		ParentHash: g.Block.ParentHash(),
+		BlockHash:  g.Block.Hash(),
+		StateRoot:  g.Block.Root(),
+	}, nil
+}
+
+// encodeL1PublicInput encodes the public input of an Ethereum block proof,
+// there is no block metadata on L1.
+func encodeL1PublicInput(p *PublicInput, _ common.Hash) ([]byte, error) {
+	transition, ok := p.transition.(*L1BlockTransition)
+	if !ok {
+		return nil, fmt.Errorf("unsupported transition type: %T", p.transition)
+	}
+	return publicInputsL1Type.Pack(
+		"VERIFY_PROOF",
+		p.chainID,
+		p.verifier,
+		transition.ParentHash,
+		transition.BlockHash,
+		transition.StateRoot,
+		p.sgxInstance,
+	)
+}
@@ -0,0 +1,139 @@
+package witness
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+)
+
+func init() {
+	registerHardFork(&hardFork{
+		name: NothingHardFork,
+		decodeProposed: func(_ []byte) (BlockProposedFork, error) {
+			return &NotingBlockProposed{}, nil
+		},
+		block: &forkProving[*GuestInput]{
+			metadata: func(_ *GuestInput) (BlockMetadataFork, error) {
+				return &NothingBlockMetadata{}, nil
+			},
+			transition: ontakeTransition,
+		},
+		encodePublicInput: encodeOntakePublicInput,
+	})
+}
+
+type NotingBlockProposed struct{}
+
+var _ BlockProposedFork = (*NotingBlockProposed)(nil)
+
+func (b *NotingBlockProposed) ABIEncode() ([]byte, error) {
+	return nil, nil
+}
+
+func (b *NotingBlockProposed) BlockNumber() uint64 {
+	return 0
+}
+
+func (b *NotingBlockProposed) BlockTimestamp() uint64 {
+	return 0
+}
+
+func (b *NotingBlockProposed) BaseFeeConfig() *pacaya.LibSharedDataBaseFeeConfig {
+	return nil
+}
+
+func (b *NotingBlockProposed) BlobTxSliceParam() *Slice {
+	return nil
+}
+
+func (b *NotingBlockProposed) BlobUsed() bool {
+	return false
+}
+
+func (b *NotingBlockProposed) HardFork() string {
+	return NothingHardFork
+}
+
+func (b *NotingBlockProposed) MinTier() uint16 {
+	return 0
+}
+
+func (b *NotingBlockProposed) ParentMetaHash() [32]byte {
+	return [32]byte{}
+}
+
+func (b *NotingBlockProposed) Sender() common.Address {
+	return common.Address{}
+}
+
+func (b *NotingBlockProposed) Difficulty() [32]byte {
+	return [32]byte{}
+}
+
+func (b *NotingBlockProposed) Proposer() common.Address {
+	return common.Address{}
+}
+
+func (b *NotingBlockProposed) LivenessBond() *big.Int {
+	return nil
+}
+
+func (b *NotingBlockProposed) ProposedAt() uint64 {
+	return 0
+}
+
+func (b *NotingBlockProposed) ProposedIn() uint64 {
+	return 0
+}
+
+func (b *NotingBlockProposed) BlobTxListOffset() uint32 {
+	return 0
+}
+
+func (b *NotingBlockProposed) BlobTxListLength() uint32 {
+	return 0
+}
+
+func (b *NotingBlockProposed) BlobIndex() uint8 {
+	return 0
+}
+
+func (b *NotingBlockProposed) GasLimit() uint32 {
+	return 0
+}
+
+func (b *NotingBlockProposed) Coinbase() common.Address {
+	return common.Address{}
+}
+
+func (b *NotingBlockProposed) BlobHashes() [][32]byte {
+	return nil
+}
+
+func (b *NotingBlockProposed) ExtraData() [32]byte {
+	return [32]byte{}
+}
+
+func (b *NotingBlockProposed) BlockParams() []*pacaya.ITaikoInboxBlockParams {
+	return nil
+}
+
+func (b *NotingBlockProposed) BlobCreatedIn() uint64 {
+	return 0
+}
+
+func (b *NotingBlockProposed) BlockMetadataFork() BlockMetadataFork {
+	return nil
+}
+
+type NothingBlockMetadata struct{}
+
+func (m *NothingBlockMetadata) ABIEncode() ([]byte, error) {
+	return nil, nil
+}
+
+func (m *NothingBlockMetadata) Hash() common.Hash {
+	return keccak.Keccak(nil)
+}
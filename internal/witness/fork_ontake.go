@@ -0,0 +1,225 @@
+package witness
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	gaikoTypes "github.com/taikoxyz/gaiko/internal/types"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+)
+
+func init() {
+	registerHardFork(&hardFork{
+		name: OntakeHardFork,
+		decodeProposed: func(data []byte) (BlockProposedFork, error) {
+			var inner gaikoTypes.BlockProposedV2
+			if err := json.Unmarshal(data, &inner); err != nil {
+				return nil, err
+			}
+			return NewOntakeBlockProposed(inner.GethType()), nil
+		},
+		proposedType: reflect.TypeFor[gaikoTypes.BlockProposedV2](),
+		encodeProposed: func(b BlockProposedFork) (any, error) {
+			proposed, ok := b.(*OntakeBlockProposed)
+			if !ok {
+				return nil, fmt.Errorf("unexpected proposal type for %s: %T", OntakeHardFork, b)
+			}
+			return gaikoTypes.NewBlockProposedV2(proposed.TaikoL1ClientBlockProposedV2), nil
+		},
+		block: &forkProving[*GuestInput]{
+			metadata:   ontakeBlockMetadata,
+			transition: ontakeTransition,
+		},
+		encodePublicInput: encodeOntakePublicInput,
+	})
+}
+
+type OntakeBlockProposed struct {
+	*ontake.TaikoL1ClientBlockProposedV2
+}
+
+var _ BlockProposedFork = (*OntakeBlockProposed)(nil)
+
+func NewOntakeBlockProposed(b *ontake.TaikoL1ClientBlockProposedV2) *OntakeBlockProposed {
+	return &OntakeBlockProposed{b}
+}
+
+func (b *OntakeBlockProposed) ABIEncode() ([]byte, error) {
+	return blockMetadataV2ComponentsArgs.Pack(b.BlockId, b.Meta)
+}
+
+func (b *OntakeBlockProposed) BlockNumber() uint64 {
+	return b.Meta.Id
+}
+
+func (b *OntakeBlockProposed) BlockTimestamp() uint64 {
+	return b.Meta.Timestamp
+}
+
+func (b *OntakeBlockProposed) BaseFeeConfig() *pacaya.LibSharedDataBaseFeeConfig {
+	return (*pacaya.LibSharedDataBaseFeeConfig)(&b.Meta.BaseFeeConfig)
+}
+
+func (b *OntakeBlockProposed) BlobTxSliceParam() *Slice {
+	return &Slice{b.Meta.BlobTxListOffset, b.Meta.BlobTxListLength}
+}
+
+func (b *OntakeBlockProposed) BlobUsed() bool {
+	return b.Meta.BlobUsed
+}
+
+func (b *OntakeBlockProposed) HardFork() string {
+	return OntakeHardFork
+}
+
+func (b *OntakeBlockProposed) MinTier() uint16 {
+	return b.Meta.MinTier
+}
+
+func (b *OntakeBlockProposed) ParentMetaHash() [32]byte {
+	return b.Meta.ParentMetaHash
+}
+
+func (b *OntakeBlockProposed) Sender() common.Address {
+	return common.Address{}
+}
+
+func (b *OntakeBlockProposed) Difficulty() [32]byte {
+	return b.Meta.Difficulty
+}
+
+func (b *OntakeBlockProposed) Proposer() common.Address {
+	return b.Meta.Proposer
+}
+
+func (b *OntakeBlockProposed) LivenessBond() *big.Int {
+	return b.Meta.LivenessBond
+}
+
+func (b *OntakeBlockProposed) ProposedAt() uint64 {
+	return b.Meta.ProposedAt
+}
+
+func (b *OntakeBlockProposed) ProposedIn() uint64 {
+	return b.Meta.ProposedIn
+}
+
+func (b *OntakeBlockProposed) BlobTxListOffset() uint32 {
+	return b.Meta.BlobTxListOffset
+}
+
+func (b *OntakeBlockProposed) BlobTxListLength() uint32 {
+	return b.Meta.BlobTxListLength
+}
+
+func (b *OntakeBlockProposed) BlobIndex() uint8 {
+	return b.Meta.BlobIndex
+}
+
+func (b *OntakeBlockProposed) GasLimit() uint32 {
+	return b.Meta.GasLimit
+}
+
+func (b *OntakeBlockProposed) Coinbase() common.Address {
+	return b.Meta.Coinbase
+}
+
+func (b *OntakeBlockProposed) BlobHashes() [][32]byte {
+	return nil
+}
+
+func (b *OntakeBlockProposed) ExtraData() [32]byte {
+	return b.Meta.ExtraData
+}
+
+func (b *OntakeBlockProposed) BlockParams() []*pacaya.ITaikoInboxBlockParams {
+	return nil
+}
+
+func (b *OntakeBlockProposed) BlobCreatedIn() uint64 {
+	return 0
+}
+
+func (b *OntakeBlockProposed) BlockMetadataFork() BlockMetadataFork {
+	return NewOntakeBlockMetadata(&b.Meta)
+}
+
+type OntakeBlockMetadata struct {
+	*ontake.TaikoDataBlockMetadataV2
+}
+
+func NewOntakeBlockMetadata(meta *ontake.TaikoDataBlockMetadataV2) *OntakeBlockMetadata {
+	return &OntakeBlockMetadata{meta}
+}
+
+func (m *OntakeBlockMetadata) ABIEncode() ([]byte, error) {
+	return blockMetadataV2ComponentsArgs.Pack(m.TaikoDataBlockMetadataV2)
+}
+
+func (m *OntakeBlockMetadata) Hash() common.Hash {
+	b, _ := m.ABIEncode()
+	return keccak.Keccak(b)
+}
+
+func ontakeBlockMetadata(g *GuestInput) (BlockMetadataFork, error) {
+	txListHash, err := g.txListHash()
+	if err != nil {
+		return nil, err
+	}
+	return NewOntakeBlockMetadata(&ontake.TaikoDataBlockMetadataV2{
+		AnchorBlockHash:  g.Taiko.L1Header.Hash(),
+		Difficulty:       g.Taiko.BlockProposed.Difficulty(),
+		BlobHash:         txListHash,
+		ExtraData:        g.extraData(),
+		Coinbase:         g.Block.Coinbase(),
+		Id:               g.Block.NumberU64(),
+		GasLimit:         g.proposedGasLimit(),
+		Timestamp:        g.Block.Time(),
+		AnchorBlockId:    g.Taiko.L1Header.Number.Uint64(),
+		MinTier:          g.Taiko.BlockProposed.MinTier(),
+		BlobUsed:         g.Taiko.BlockProposed.BlobUsed(),
+		ParentMetaHash:   g.Taiko.BlockProposed.ParentMetaHash(),
+		Proposer:         g.Taiko.BlockProposed.Proposer(),
+		LivenessBond:     g.Taiko.BlockProposed.LivenessBond(),
+		ProposedAt:       g.Taiko.BlockProposed.ProposedAt(),
+		ProposedIn:       g.Taiko.BlockProposed.ProposedIn(),
+		BlobTxListOffset: g.Taiko.BlockProposed.BlobTxListOffset(),
+		BlobTxListLength: g.Taiko.BlockProposed.BlobTxListLength(),
+		BlobIndex:        g.Taiko.BlockProposed.BlobIndex(),
+		BaseFeeConfig: ontake.LibSharedDataBaseFeeConfig(
+			*g.Taiko.BlockProposed.BaseFeeConfig(),
+		),
+	}), nil
+}
+
+// ontakeTransition is the transition proven for a single block, shared by the
+// forks before Pacaya.
+func ontakeTransition(g *GuestInput) (any, error) {
+	return &ontake.TaikoDataTransition{
+		ParentHash: g.ParentHeader.Hash(),
+		BlockHash:  g.Block.Hash(),
+		StateRoot:  g.Block.Root(),
+		Graffiti:   g.Taiko.ProverData.Graffiti,
+	}, nil
+}
+
+func encodeOntakePublicInput(p *PublicInput, metaHash common.Hash) ([]byte, error) {
+	transition, ok := p.transition.(*ontake.TaikoDataTransition)
+	if !ok {
+		return nil, fmt.Errorf("unsupported transition type: %T", p.transition)
+	}
+	return publicInputsV1Type.Pack(
+		"VERIFY_PROOF",
+		p.chainID,
+		p.verifier,
+		transition,
+		p.sgxInstance,
+		p.prover,
+		metaHash,
+	)
+}
@@ -0,0 +1,281 @@
+package witness
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	gaikoTypes "github.com/taikoxyz/gaiko/internal/types"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+)
+
+func init() {
+	registerHardFork(&hardFork{
+		name: PacayaHardFork,
+		decodeProposed: func(data []byte) (BlockProposedFork, error) {
+			var inner gaikoTypes.BatchProposed
+			if err := json.Unmarshal(data, &inner); err != nil {
+				return nil, err
+			}
+			return NewPacayaBlockProposed(inner.GethType()), nil
+		},
+		proposedType: reflect.TypeFor[gaikoTypes.BatchProposed](),
+		encodeProposed: func(b BlockProposedFork) (any, error) {
+			proposed, ok := b.(*PacayaBlockProposed)
+			if !ok {
+				return nil, fmt.Errorf("unexpected proposal type for %s: %T", PacayaHardFork, b)
+			}
+			return gaikoTypes.NewBatchProposed(proposed.TaikoInboxClientBatchProposed), nil
+		},
+		batch: &forkProving[*BatchGuestInput]{
+			metadata:   pacayaBatchMetadata,
+			transition: pacayaTransition,
+		},
+		encodePublicInput: encodePacayaPublicInput,
+	})
+}
+
+var _ BlockProposedFork = (*PacayaBlockProposed)(nil)
+
+type PacayaBlockProposed struct {
+	*pacaya.TaikoInboxClientBatchProposed
+	blockParams []*pacaya.ITaikoInboxBlockParams
+}
+
+func NewPacayaBlockProposed(b *pacaya.TaikoInboxClientBatchProposed) *PacayaBlockProposed {
+	blockParams := make([]*pacaya.ITaikoInboxBlockParams, len(b.Info.Blocks))
+	for i, block := range b.Info.Blocks {
+		blockParams[i] = &block
+	}
+
+	return &PacayaBlockProposed{
+		TaikoInboxClientBatchProposed: b,
+		blockParams:                   blockParams,
+	}
+}
+
+func (b *PacayaBlockProposed) ABIEncode() ([]byte, error) {
+	return batchProposedEvent.Inputs.Pack(b.Info, b.Meta, b.TxList)
+}
+
+func (b *PacayaBlockProposed) BlockNumber() uint64 {
+	return b.Info.LastBlockId - uint64(len(b.Info.Blocks)) + 1
+}
+
+func (b *PacayaBlockProposed) BlockTimestamp() uint64 {
+	return 0
+}
+
+func (b *PacayaBlockProposed) BaseFeeConfig() *pacaya.LibSharedDataBaseFeeConfig {
+	return &b.Info.BaseFeeConfig
+}
+
+func (b *PacayaBlockProposed) BlobTxSliceParam() *Slice {
+	return &Slice{b.Info.BlobByteOffset, b.Info.BlobByteSize}
+}
+
+func (b *PacayaBlockProposed) BlobUsed() bool {
+	return len(b.BlobHashes()) > 0
+}
+
+func (b *PacayaBlockProposed) HardFork() string {
+	return PacayaHardFork
+}
+
+func (b *PacayaBlockProposed) MinTier() uint16 {
+	return 0
+}
+
+func (b *PacayaBlockProposed) ParentMetaHash() [32]byte {
+	return [32]byte{}
+}
+
+func (b *PacayaBlockProposed) Sender() common.Address {
+	return common.Address{}
+}
+
+func (b *PacayaBlockProposed) Difficulty() [32]byte {
+	return [32]byte{}
+}
+
+func (b *PacayaBlockProposed) Proposer() common.Address {
+	return b.Meta.Proposer
+}
+
+func (b *PacayaBlockProposed) LivenessBond() *big.Int {
+	return nil
+}
+
+func (b *PacayaBlockProposed) ProposedAt() uint64 {
+	return b.Meta.ProposedAt
+}
+
+func (b *PacayaBlockProposed) ProposedIn() uint64 {
+	return b.Info.ProposedIn
+}
+
+func (b *PacayaBlockProposed) BlobTxListOffset() uint32 {
+	return b.Info.BlobByteOffset
+}
+
+func (b *PacayaBlockProposed) BlobTxListLength() uint32 {
+	return b.Info.BlobByteSize
+}
+
+func (b *PacayaBlockProposed) BlobIndex() uint8 {
+	return 0
+}
+
+func (b *PacayaBlockProposed) GasLimit() uint32 {
+	return b.Info.GasLimit
+}
+func (b *PacayaBlockProposed) Coinbase() common.Address {
+	return b.Info.Coinbase
+}
+
+func (b *PacayaBlockProposed) BlobHashes() [][32]byte {
+	return b.Info.BlobHashes
+}
+
+func (b *PacayaBlockProposed) ExtraData() [32]byte {
+	return b.Info.ExtraData
+}
+
+func (b *PacayaBlockProposed) BlockParams() []*pacaya.ITaikoInboxBlockParams {
+	return b.blockParams
+}
+
+func (b *PacayaBlockProposed) BlobCreatedIn() uint64 {
+	return b.Info.BlobCreatedIn
+}
+
+func (b *PacayaBlockProposed) BlockMetadataFork() BlockMetadataFork {
+	return NewPacayaBlockMetadata(&b.Meta)
+}
+
+type PacayaBlockMetadata struct {
+	*pacaya.ITaikoInboxBatchMetadata
+}
+
+func NewPacayaBlockMetadata(meta *pacaya.ITaikoInboxBatchMetadata) *PacayaBlockMetadata {
+	return &PacayaBlockMetadata{meta}
+}
+
+func (m *PacayaBlockMetadata) ABIEncode() ([]byte, error) {
+	return batchMetadataComponentsArgs.Pack(m.ITaikoInboxBatchMetadata)
+}
+
+func (m *PacayaBlockMetadata) Hash() common.Hash {
+	b, _ := m.ABIEncode()
+	return keccak.Keccak(b)
+}
+
+func calculatePacayaTxsHash(
+	txListHash common.Hash,
+	blobHashes [][32]byte,
+) (common.Hash, error) {
+	data, err := batchTxHashArgs.Pack(txListHash, blobHashes)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return keccak.Keccak(data), nil
+}
+
+func pacayaBatchMetadata(g *BatchGuestInput) (BlockMetadataFork, error) {
+	txListHash := keccak.Keccak(g.Taiko.TxDataFromCalldata)
+	txsHash, err := calculatePacayaTxsHash(txListHash, g.Taiko.BatchProposed.BlobHashes())
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]pacaya.ITaikoInboxBlockParams, 0, len(g.Inputs))
+	parentTs := g.Inputs[0].Block.Time()
+
+	if len(g.Inputs) != len(g.Taiko.BatchProposed.BlockParams()) {
+		return nil, fmt.Errorf(
+			"mismatched inputs: %d and block parameters: %d length",
+			len(g.Inputs),
+			len(g.Taiko.BatchProposed.BlockParams()),
+		)
+	}
+	for idx, input := range g.Inputs {
+		signalSlots, err := decodeAnchorV3Args_signalSlots(input.Taiko.AnchorTx.Data()[4:])
+		if err != nil {
+			return nil, err
+		}
+		if input.Block.Time() < parentTs || (input.Block.Time()-parentTs) > math.MaxUint8 {
+			return nil, fmt.Errorf(
+				"invalid delta block time, parent: %d, current: %d",
+				parentTs,
+				input.Block.Time(),
+			)
+		}
+		blockParams := pacaya.ITaikoInboxBlockParams{
+			NumTransactions: g.Taiko.BatchProposed.BlockParams()[idx].NumTransactions,
+			TimeShift:       uint8(input.Block.Time() - parentTs),
+			SignalSlots:     signalSlots,
+		}
+		parentTs = input.Block.Time()
+		blocks = append(blocks, blockParams)
+	}
+
+	batchInfo := &pacaya.ITaikoInboxBatchInfo{
+		TxsHash:            txsHash,
+		Blocks:             blocks,
+		BlobHashes:         g.Taiko.BatchProposed.BlobHashes(),
+		ExtraData:          g.Taiko.BatchProposed.ExtraData(),
+		Coinbase:           g.Taiko.BatchProposed.Coinbase(),
+		ProposedIn:         g.Taiko.BatchProposed.ProposedIn(),
+		BlobByteOffset:     g.Taiko.BatchProposed.BlobTxListOffset(),
+		BlobByteSize:       g.Taiko.BatchProposed.BlobTxListLength(),
+		GasLimit:           g.Taiko.BatchProposed.GasLimit(),
+		LastBlockId:        g.Inputs[len(g.Inputs)-1].Block.NumberU64(),
+		LastBlockTimestamp: g.Inputs[len(g.Inputs)-1].Block.Time(),
+		AnchorBlockId:      g.Taiko.L1Header.Number.Uint64(),
+		AnchorBlockHash:    g.Taiko.L1Header.Hash(),
+		BaseFeeConfig:      *g.Taiko.BatchProposed.BaseFeeConfig(),
+		BlobCreatedIn:      g.Taiko.BatchProposed.BlobCreatedIn(),
+	}
+
+	data, err := batchInfoComponentsArgs.Pack(batchInfo)
+	if err != nil {
+		return nil, err
+	}
+	infoHash := keccak.Keccak(data)
+
+	return NewPacayaBlockMetadata(&pacaya.ITaikoInboxBatchMetadata{
+		InfoHash:   infoHash,
+		Proposer:   g.Taiko.BatchProposed.Proposer(),
+		BatchId:    g.Taiko.BatchID,
+		ProposedAt: g.Taiko.BatchProposed.ProposedAt(),
+	}), nil
+}
+
+func pacayaTransition(g *BatchGuestInput) (any, error) {
+	firstBlock := g.Inputs[0].Block
+	lastBlock := g.Inputs[len(g.Inputs)-1].Block
+	return &pacaya.ITaikoInboxTransition{
+		ParentHash: firstBlock.ParentHash(),
+		BlockHash:  lastBlock.Hash(),
+		StateRoot:  lastBlock.Root(),
+	}, nil
+}
+
+func encodePacayaPublicInput(p *PublicInput, metaHash common.Hash) ([]byte, error) {
+	transition, ok := p.transition.(*pacaya.ITaikoInboxTransition)
+	if !ok {
+		return nil, fmt.Errorf("unsupported transition type: %T", p.transition)
+	}
+	return publicInputsV2Type.Pack(
+		"VERIFY_PROOF",
+		p.chainID,
+		p.verifier,
+		transition,
+		p.sgxInstance,
+		metaHash,
+	)
+}
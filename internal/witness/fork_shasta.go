@@ -0,0 +1,330 @@
+package witness
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	gaikoTypes "github.com/taikoxyz/gaiko/internal/types"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+)
+
+func init() {
+	registerHardFork(&hardFork{
+		name: ShastaHardFork,
+		decodeProposed: func(data []byte) (BlockProposedFork, error) {
+			var inner gaikoTypes.ShastaProposed
+			if err := json.Unmarshal(data, &inner); err != nil {
+				return nil, err
+			}
+			return NewShastaBlockProposed(inner.GethType()), nil
+		},
+		proposedType: reflect.TypeFor[gaikoTypes.ShastaProposed](),
+		encodeProposed: func(b BlockProposedFork) (any, error) {
+			proposed, ok := b.(*ShastaBlockProposed)
+			if !ok {
+				return nil, fmt.Errorf("unexpected proposal type for %s: %T", ShastaHardFork, b)
+			}
+			return gaikoTypes.NewShastaProposed(proposed.InboxProposed), nil
+		},
+		batch: &forkProving[*BatchGuestInput]{
+			metadata:   shastaBatchMetadata,
+			transition: shastaTransition,
+		},
+		encodePublicInput: encodeShastaPublicInput,
+	})
+}
+
+var _ BlockProposedFork = (*ShastaBlockProposed)(nil)
+
+// ShastaBlockProposed is a proposal of the Shasta Inbox, the L2 blocks of a
+// proposal are proven together like a Pacaya batch.
+type ShastaBlockProposed struct {
+	*gaikoTypes.InboxProposed
+}
+
+func NewShastaBlockProposed(p *gaikoTypes.InboxProposed) *ShastaBlockProposed {
+	return &ShastaBlockProposed{p}
+}
+
+func (b *ShastaBlockProposed) ABIEncode() ([]byte, error) {
+	return shastaProposedArgs.Pack(b.Proposal, b.Derivation)
+}
+
+// BlockNumber returns 0, shasta proposals don't commit to L2 block numbers.
+func (b *ShastaBlockProposed) BlockNumber() uint64 {
+	return 0
+}
+
+func (b *ShastaBlockProposed) BlockTimestamp() uint64 {
+	return b.Proposal.Timestamp.Uint64()
+}
+
+func (b *ShastaBlockProposed) BaseFeeConfig() *pacaya.LibSharedDataBaseFeeConfig {
+	return nil
+}
+
+func (b *ShastaBlockProposed) BlobTxSliceParam() *Slice {
+	return nil
+}
+
+func (b *ShastaBlockProposed) BlobUsed() bool {
+	return len(b.BlobHashes()) > 0
+}
+
+func (b *ShastaBlockProposed) HardFork() string {
+	return ShastaHardFork
+}
+
+func (b *ShastaBlockProposed) MinTier() uint16 {
+	return 0
+}
+
+func (b *ShastaBlockProposed) ParentMetaHash() [32]byte {
+	return [32]byte{}
+}
+
+func (b *ShastaBlockProposed) Sender() common.Address {
+	return common.Address{}
+}
+
+func (b *ShastaBlockProposed) Difficulty() [32]byte {
+	return [32]byte{}
+}
+
+func (b *ShastaBlockProposed) Proposer() common.Address {
+	return b.Proposal.Proposer
+}
+
+func (b *ShastaBlockProposed) LivenessBond() *big.Int {
+	return nil
+}
+
+func (b *ShastaBlockProposed) ProposedAt() uint64 {
+	return b.Proposal.Timestamp.Uint64()
+}
+
+func (b *ShastaBlockProposed) ProposedIn() uint64 {
+	return b.Derivation.OriginBlockNumber.Uint64()
+}
+
+func (b *ShastaBlockProposed) BlobTxListOffset() uint32 {
+	return uint32(b.Derivation.BlobSlice.Offset.Uint64())
+}
+
+func (b *ShastaBlockProposed) BlobTxListLength() uint32 {
+	return 0
+}
+
+func (b *ShastaBlockProposed) BlobIndex() uint8 {
+	return 0
+}
+
+func (b *ShastaBlockProposed) GasLimit() uint32 {
+	return 0
+}
+
+func (b *ShastaBlockProposed) Coinbase() common.Address {
+	return common.Address{}
+}
+
+func (b *ShastaBlockProposed) BlobHashes() [][32]byte {
+	return b.Derivation.BlobSlice.BlobHashes
+}
+
+func (b *ShastaBlockProposed) ExtraData() [32]byte {
+	return [32]byte{}
+}
+
+func (b *ShastaBlockProposed) BlockParams() []*pacaya.ITaikoInboxBlockParams {
+	return nil
+}
+
+func (b *ShastaBlockProposed) BlobCreatedIn() uint64 {
+	return 0
+}
+
+func (b *ShastaBlockProposed) BlockMetadataFork() BlockMetadataFork {
+	return NewShastaBlockMetadata(&b.Proposal)
+}
+
+// ShastaBlockMetadata is the proposal stored by the Inbox, its hash is what a
+// Shasta transition commits to.
+type ShastaBlockMetadata struct {
+	*gaikoTypes.IInboxProposal
+}
+
+func NewShastaBlockMetadata(proposal *gaikoTypes.IInboxProposal) *ShastaBlockMetadata {
+	return &ShastaBlockMetadata{proposal}
+}
+
+func (m *ShastaBlockMetadata) ABIEncode() ([]byte, error) {
+	return shastaProposalComponentsArgs.Pack(m.IInboxProposal)
+}
+
+func (m *ShastaBlockMetadata) Hash() common.Hash {
+	b, _ := m.ABIEncode()
+	return keccak.Keccak(b)
+}
+
+// shastaBatchMetadata rebuilds the proposal from the witness: the derivation is
+// recomputed from the anchored L1 header, the supplied blobs and the executed
+// blocks, so a proposal whose derivation hash doesn't match the witness fails
+// the metadata check. The blocks must also be the ones the manifest in the
+// blobs lists.
+func shastaBatchMetadata(g *BatchGuestInput) (BlockMetadataFork, error) {
+	proposed, ok := g.Taiko.BatchProposed.(*ShastaBlockProposed)
+	if !ok {
+		return nil, fmt.Errorf("unexpected proposal type for %s: %T", ShastaHardFork, g.Taiko.BatchProposed)
+	}
+	blobHashes, err := shastaBlobHashes(g)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Equal(blobHashes, proposed.BlobHashes()) {
+		return nil, fmt.Errorf(
+			"blob hashes mismatch, expected: %x, got: %x",
+			proposed.BlobHashes(), blobHashes,
+		)
+	}
+	if blocks := shastaManifest(g, proposed).Blocks; len(blocks) != len(g.Inputs) {
+		return nil, fmt.Errorf("mismatched inputs: %d and manifest blocks: %d", len(g.Inputs), len(blocks))
+	}
+	for pair := range g.GuestInputs() {
+		if err := checkBlockBody(pair.Input.Block, pair.Txs); err != nil {
+			return nil, err
+		}
+	}
+	basefeeSharingPctg, err := shastaBasefeeSharingPctg(g)
+	if err != nil {
+		return nil, err
+	}
+
+	// forced inclusions keep the timestamp of the blobs they were stored with,
+	// the blobs of a regular proposal are posted with the proposal itself.
+	// Whether the proposal is a forced inclusion isn't recorded in the blocks,
+	// it comes from the proposal.
+	isForcedInclusion := proposed.Derivation.IsForcedInclusion
+	blobTimestamp := new(big.Int).Set(proposed.Proposal.Timestamp)
+	if isForcedInclusion {
+		blobTimestamp = new(big.Int).Set(proposed.Derivation.BlobSlice.Timestamp)
+		if blobTimestamp.Cmp(proposed.Proposal.Timestamp) >= 0 {
+			return nil, fmt.Errorf(
+				"forced inclusion blobs at %s aren't older than the proposal at %s",
+				blobTimestamp, proposed.Proposal.Timestamp,
+			)
+		}
+	}
+	derivation := gaikoTypes.IInboxDerivation{
+		OriginBlockNumber:  new(big.Int).Set(g.Taiko.L1Header.Number),
+		OriginBlockHash:    g.Taiko.L1Header.Hash(),
+		IsForcedInclusion:  isForcedInclusion,
+		BasefeeSharingPctg: basefeeSharingPctg,
+		BlobSlice: gaikoTypes.LibBlobsBlobSlice{
+			BlobHashes: blobHashes,
+			// the manifest of the blocks above was decoded at this offset
+			Offset:    new(big.Int).SetUint64(uint64(proposed.BlobTxListOffset())),
+			Timestamp: blobTimestamp,
+		},
+	}
+	data, err := shastaDerivationComponentsArgs.Pack(&derivation)
+	if err != nil {
+		return nil, err
+	}
+	proposal := proposed.Proposal
+	proposal.DerivationHash = keccak.Keccak(data)
+	return NewShastaBlockMetadata(&proposal), nil
+}
+
+// shastaBlobHashes returns the versioned hashes of the blobs of g. The
+// commitments are computed from the blobs, the witness must carry the same
+// commitments or, for proof of equivalence, proofs that open them.
+func shastaBlobHashes(g *BatchGuestInput) ([][32]byte, error) {
+	blobs := g.Taiko.TxDataFromBlob
+	commitments, proofs := g.Taiko.BlobCommitments, g.Taiko.BlobProofs
+	hasCommitments := commitments != nil && len(*commitments) == len(blobs)
+	if len(blobs) != 0 && !hasCommitments && (proofs == nil || len(*proofs) != len(blobs)) {
+		return nil, fmt.Errorf("missing blob commitments or proofs for %d blobs", len(blobs))
+	}
+	hashes := make([][32]byte, 0, len(blobs))
+	for i := range blobs {
+		blob := eth.Blob(blobs[i])
+		commitment, err := blob.ComputeKZGCommitment()
+		if err != nil {
+			return nil, err
+		}
+		if hasCommitments {
+			if want := kzg4844.Commitment((*commitments)[i]); commitment != want {
+				return nil, fmt.Errorf("blob %d commitment mismatch, expected: %#x, got: %#x", i, want, commitment)
+			}
+		} else {
+			proof := kzg4844.Proof((*proofs)[i])
+			if err := verifyBlob(ProofOfEquivalence, &blob, commitment, &proof); err != nil {
+				return nil, fmt.Errorf("blob %d: %w", i, err)
+			}
+		}
+		hashes = append(hashes, eth.KZGToVersionedHash(commitment))
+	}
+	return hashes, nil
+}
+
+// shastaBasefeeSharingPctg returns the basefee sharing percentage the blocks
+// of g were executed with, the first byte of their extra data.
+func shastaBasefeeSharingPctg(g *BatchGuestInput) (uint8, error) {
+	var pctg uint8
+	for i, input := range g.Inputs {
+		extra := input.Block.Extra()
+		if len(extra) == 0 {
+			return 0, fmt.Errorf("block %d has no basefee sharing percentage", input.Block.NumberU64())
+		}
+		if i > 0 && extra[0] != pctg {
+			return 0, fmt.Errorf(
+				"block %d basefee sharing percentage mismatch, expected: %d, got: %d",
+				input.Block.NumberU64(), pctg, extra[0],
+			)
+		}
+		pctg = extra[0]
+	}
+	return pctg, nil
+}
+
+func shastaTransition(g *BatchGuestInput) (any, error) {
+	proposed, ok := g.Taiko.BatchProposed.(*ShastaBlockProposed)
+	if !ok {
+		return nil, fmt.Errorf("unexpected proposal type for %s: %T", ShastaHardFork, g.Taiko.BatchProposed)
+	}
+	lastBlock := g.Inputs[len(g.Inputs)-1].Block
+	return &gaikoTypes.IInboxTransition{
+		ProposalHash:         proposed.BlockMetadataFork().Hash(),
+		ParentTransitionHash: proposed.ParentTransitionHash,
+		Checkpoint: gaikoTypes.ICheckpointStoreCheckpoint{
+			BlockNumber: lastBlock.Number(),
+			BlockHash:   lastBlock.Hash(),
+			StateRoot:   lastBlock.Root(),
+		},
+		DesignatedProver: proposed.DesignatedProver,
+		ActualProver:     g.Prover(),
+	}, nil
+}
+
+// encodeShastaPublicInput encodes the public input of a Shasta proof, the
+// proposal hash is part of the transition so there is no separate meta hash.
+func encodeShastaPublicInput(p *PublicInput, _ common.Hash) ([]byte, error) {
+	transition, ok := p.transition.(*gaikoTypes.IInboxTransition)
+	if !ok {
+		return nil, fmt.Errorf("unsupported transition type: %T", p.transition)
+	}
+	return publicInputsV3Type.Pack(
+		"VERIFY_PROOF",
+		p.chainID,
+		p.verifier,
+		transition,
+		p.sgxInstance,
+	)
+}
@@ -17,11 +17,11 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/taikoxyz/gaiko/pkg/keccak"
 	"github.com/taikoxyz/gaiko/pkg/mpt"
-	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
 )
 
 var _ WitnessInput = (*GuestInput)(nil)
 var _ json.Unmarshaler = (*GuestInput)(nil)
+var _ json.Marshaler = (*GuestInput)(nil)
 
 type GuestInput struct {
 	parent          *BatchGuestInput
@@ -32,7 +32,10 @@ type GuestInput struct {
 	ParentStorage   map[common.Address]*StorageEntry
 	Contracts       [][]byte
 	AncestorHeaders []*types.Header
-	Taiko           *TaikoGuestInput
+	// ExecutionWitness replaces the state tries, contracts and headers above
+	// when the witness comes flat from geth.
+	ExecutionWitness *ExecutionWitness
+	Taiko            *TaikoGuestInput
 }
 
 type StorageEntry struct {
@@ -135,85 +138,48 @@ func (g *GuestInput) Verify(proofType ProofType) error {
 }
 
 func (g *GuestInput) BlockMetadataFork() (BlockMetadataFork, error) {
-	var (
-		reducedGasLimit uint32
-		txListHash      common.Hash
-		metadata        BlockMetadataFork
-	)
-	if g.IsTaiko() {
-		reducedGasLimit = anchorGasLimit
+	fork, err := lookupBlockHardFork(g.Taiko.BlockProposed.HardFork())
+	if err != nil {
+		return nil, err
 	}
+	return fork.metadata(g)
+}
 
-	if g.Taiko.BlockProposed.BlobUsed() {
-		if g.Taiko.BlobCommitment == nil {
-			return nil, errors.New("missing blob commitment")
-		}
-		commitment := kzg4844.Commitment(*g.Taiko.BlobCommitment)
-		txListHash = eth.KZGToVersionedHash(commitment)
-	} else {
-		txListHash = keccak.Keccak(g.Taiko.TxData)
+func (g *GuestInput) Transition() (any, error) {
+	fork, err := lookupBlockHardFork(g.Taiko.BlockProposed.HardFork())
+	if err != nil {
+		return nil, err
 	}
+	return fork.transition(g)
+}
 
-	var extraData [32]byte
-	copy(extraData[:], g.Block.Extra())
-	switch g.Taiko.BlockProposed.HardFork() {
-	case NothingHardFork:
-		metadata = &NothingBlockMetadata{}
-	case HeklaHardFork:
-		metadata = NewHeklaBlockMetadata(&ontake.TaikoDataBlockMetadata{
-			L1Hash:         g.Taiko.L1Header.Hash(),
-			Difficulty:     g.Taiko.BlockProposed.Difficulty(),
-			BlobHash:       txListHash,
-			ExtraData:      extraData,
-			DepositsHash:   emptyEthDepositHash,
-			Coinbase:       g.Block.Coinbase(),
-			Id:             g.Block.NumberU64(),
-			GasLimit:       uint32(g.Block.GasLimit()) - reducedGasLimit,
-			Timestamp:      g.Block.Time(),
-			L1Height:       g.Taiko.L1Header.Number.Uint64(),
-			MinTier:        g.Taiko.BlockProposed.MinTier(),
-			BlobUsed:       g.Taiko.BlockProposed.BlobUsed(),
-			ParentMetaHash: g.Taiko.BlockProposed.ParentMetaHash(),
-			Sender:         g.Taiko.BlockProposed.Sender(),
-		})
-	case OntakeHardFork:
-		metadata = NewOntakeBlockMetadata(&ontake.TaikoDataBlockMetadataV2{
-			AnchorBlockHash:  g.Taiko.L1Header.Hash(),
-			Difficulty:       g.Taiko.BlockProposed.Difficulty(),
-			BlobHash:         txListHash,
-			ExtraData:        extraData,
-			Coinbase:         g.Block.Coinbase(),
-			Id:               g.Block.NumberU64(),
-			GasLimit:         uint32(g.Block.GasLimit()) - reducedGasLimit,
-			Timestamp:        g.Block.Time(),
-			AnchorBlockId:    g.Taiko.L1Header.Number.Uint64(),
-			MinTier:          g.Taiko.BlockProposed.MinTier(),
-			BlobUsed:         g.Taiko.BlockProposed.BlobUsed(),
-			ParentMetaHash:   g.Taiko.BlockProposed.ParentMetaHash(),
-			Proposer:         g.Taiko.BlockProposed.Proposer(),
-			LivenessBond:     g.Taiko.BlockProposed.LivenessBond(),
-			ProposedAt:       g.Taiko.BlockProposed.ProposedAt(),
-			ProposedIn:       g.Taiko.BlockProposed.ProposedIn(),
-			BlobTxListOffset: g.Taiko.BlockProposed.BlobTxListOffset(),
-			BlobTxListLength: g.Taiko.BlockProposed.BlobTxListLength(),
-			BlobIndex:        g.Taiko.BlockProposed.BlobIndex(),
-			BaseFeeConfig: ontake.LibSharedDataBaseFeeConfig(
-				*g.Taiko.BlockProposed.BaseFeeConfig(),
-			),
-		})
-	default:
-		return nil, fmt.Errorf("unsupported hardfork: %s", g.Taiko.BlockProposed.HardFork())
+// txListHash returns the hash committed for the tx list, the versioned hash of
+// the blob if used, otherwise the keccak hash of the calldata.
+func (g *GuestInput) txListHash() (common.Hash, error) {
+	if !g.Taiko.BlockProposed.BlobUsed() {
+		return keccak.Keccak(g.Taiko.TxData), nil
 	}
-	return metadata, nil
+	if g.Taiko.BlobCommitment == nil {
+		return common.Hash{}, errors.New("missing blob commitment")
+	}
+	commitment := kzg4844.Commitment(*g.Taiko.BlobCommitment)
+	return eth.KZGToVersionedHash(commitment), nil
 }
 
-func (g *GuestInput) Transition() any {
-	return &ontake.TaikoDataTransition{
-		ParentHash: g.ParentHeader.Hash(),
-		BlockHash:  g.Block.Hash(),
-		StateRoot:  g.Block.Root(),
-		Graffiti:   g.Taiko.ProverData.Graffiti,
+// proposedGasLimit returns the gas limit of the block as proposed on L1,
+// excluding the gas reserved for the anchor transaction.
+func (g *GuestInput) proposedGasLimit() uint32 {
+	var reducedGasLimit uint32
+	if g.IsTaiko() {
+		reducedGasLimit = anchorGasLimit
 	}
+	return uint32(g.Block.GasLimit()) - reducedGasLimit
+}
+
+func (g *GuestInput) extraData() [32]byte {
+	var extraData [32]byte
+	copy(extraData[:], g.Block.Extra())
+	return extraData
 }
 
 func (g *GuestInput) ForkVerifierAddress(proofType ProofType) common.Address {
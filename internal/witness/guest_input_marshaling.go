@@ -12,17 +12,20 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	gaikoTypes "github.com/taikoxyz/gaiko/internal/types"
 	"github.com/taikoxyz/gaiko/pkg/mpt"
+	"github.com/taikoxyz/gaiko/pkg/serde"
 )
 
 type guestInputJSON struct {
-	Block           *gaikoTypes.Block                `json:"block"`
-	ChainSpec       *ChainSpec                       `json:"chain_spec"`
-	ParentHeader    *gaikoTypes.Header               `json:"parent_header"`
-	ParentStateTrie *mpt.MptNode                     `json:"parent_state_trie"`
-	ParentStorage   map[common.Address]*StorageEntry `json:"parent_storage"`
-	Contracts       []hexutil.Bytes                  `json:"contracts"`
-	AncestorHeaders []*gaikoTypes.Header             `json:"ancestor_headers"`
-	Taiko           *taikoGuestInputJSON             `json:"taiko"`
+	SchemaVersion    SchemaVersion                    `json:"schema_version,omitempty"`
+	Block            *gaikoTypes.Block                `json:"block"`
+	ChainSpec        *ChainSpec                       `json:"chain_spec"`
+	ParentHeader     *gaikoTypes.Header               `json:"parent_header"`
+	ParentStateTrie  *mpt.MptNode                     `json:"parent_state_trie"`
+	ParentStorage    map[common.Address]*StorageEntry `json:"parent_storage"`
+	Contracts        []hexutil.Bytes                  `json:"contracts"`
+	AncestorHeaders  []*gaikoTypes.Header             `json:"ancestor_headers"`
+	ExecutionWitness *ExecutionWitness                `json:"execution_witness,omitempty"`
+	Taiko            *taikoGuestInputJSON             `json:"taiko"`
 }
 
 func (g *guestInputJSON) GethType() *GuestInput {
@@ -39,27 +42,88 @@ func (g *guestInputJSON) GethType() *GuestInput {
 	for i, ancestorHeader := range g.AncestorHeaders {
 		ancestorHeaders[i] = ancestorHeader.GethType()
 	}
+	parentHeader := g.ParentHeader.GethType()
+	if ew := g.ExecutionWitness; ew != nil && len(ew.Headers) != 0 {
+		// the flat witness may stand in for the headers as well
+		if g.ParentHeader == nil {
+			parentHeader = ew.Headers[0]
+		}
+		if len(ancestorHeaders) == 0 {
+			ancestorHeaders = ew.Headers[1:]
+		}
+	}
 	return &GuestInput{
-		Block:           g.Block.GethType(),
-		ChainSpec:       g.ChainSpec,
-		ParentHeader:    g.ParentHeader.GethType(),
-		ParentStateTrie: g.ParentStateTrie,
-		ParentStorage:   g.ParentStorage,
-		Contracts:       contracts,
-		AncestorHeaders: ancestorHeaders,
-		Taiko:           g.Taiko.GethType(),
+		Block:            g.Block.GethType(),
+		ChainSpec:        g.ChainSpec,
+		ParentHeader:     parentHeader,
+		ParentStateTrie:  g.ParentStateTrie,
+		ParentStorage:    g.ParentStorage,
+		Contracts:        contracts,
+		AncestorHeaders:  ancestorHeaders,
+		ExecutionWitness: g.ExecutionWitness,
+		Taiko:            g.Taiko.GethType(),
+	}
+}
+
+// intern shares the nodes of the state and storage tries with the other
+// inputs of pool.
+func (g *guestInputJSON) intern(pool *mpt.Pool) error {
+	if g.ParentStateTrie != nil {
+		if err := pool.Intern(g.ParentStateTrie); err != nil {
+			return err
+		}
+	}
+	for _, entry := range g.ParentStorage {
+		if entry == nil || entry.Trie == nil {
+			continue
+		}
+		if err := pool.Intern(entry.Trie); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newGuestInputJSON(g *GuestInput) (*guestInputJSON, error) {
+	block, err := gaikoTypes.NewBlock(g.Block)
+	if err != nil {
+		return nil, err
+	}
+	taiko, err := newTaikoGuestInputJSON(g.Taiko)
+	if err != nil {
+		return nil, err
 	}
+	contracts := make([]hexutil.Bytes, len(g.Contracts))
+	for i, contract := range g.Contracts {
+		contracts[i] = contract
+	}
+	parentStorage := g.ParentStorage
+	if parentStorage == nil {
+		parentStorage = map[common.Address]*StorageEntry{}
+	}
+	return &guestInputJSON{
+		SchemaVersion:    CurrentSchemaVersion,
+		Block:            block,
+		ChainSpec:        g.ChainSpec,
+		ParentHeader:     gaikoTypes.NewHeader(g.ParentHeader),
+		ParentStateTrie:  g.ParentStateTrie,
+		ParentStorage:    parentStorage,
+		Contracts:        contracts,
+		AncestorHeaders:  gaikoTypes.NewHeaders(g.AncestorHeaders),
+		ExecutionWitness: g.ExecutionWitness,
+		Taiko:            taiko,
+	}, nil
 }
 
 type taikoGuestInputJSON struct {
 	L1Header       *gaikoTypes.Header            `json:"l1_header"`
-	TxData         []byte                        `json:"tx_data"`
+	TxData         serde.ByteList                `json:"tx_data"`
 	AnchorTx       *gaikoTypes.TransactionSigned `json:"anchor_tx"`
 	BlockProposed  *blockProposedForkJSON        `json:"block_proposed"`
 	ProverData     *TaikoProverData              `json:"prover_data"`
 	BlobCommitment *[commitmentSize]byte         `json:"blob_commitment"`
 	BlobProof      *[proofSize]byte              `json:"blob_proof"`
-	BlobProofType  BlobProofType                 `json:"blob_proof_type"`
+	BlobProofType  BlobProofType                 `json:"blob_proof_type" since:"2"`
 }
 
 func (t *taikoGuestInputJSON) GethType() *TaikoGuestInput {
@@ -79,63 +143,89 @@ func (t *taikoGuestInputJSON) GethType() *TaikoGuestInput {
 	}
 }
 
+func newTaikoGuestInputJSON(t *TaikoGuestInput) (*taikoGuestInputJSON, error) {
+	if t == nil {
+		return nil, nil
+	}
+	anchorTx, err := gaikoTypes.NewTransactionSigned(t.AnchorTx)
+	if err != nil {
+		return nil, fmt.Errorf("anchor tx: %w", err)
+	}
+	return &taikoGuestInputJSON{
+		L1Header:       gaikoTypes.NewHeader(t.L1Header),
+		TxData:         t.TxData,
+		AnchorTx:       anchorTx,
+		BlockProposed:  newBlockProposedForkJSON(t.BlockProposed),
+		ProverData:     t.ProverData,
+		BlobCommitment: t.BlobCommitment,
+		BlobProof:      t.BlobProof,
+		BlobProofType:  t.BlobProofType,
+	}, nil
+}
+
 type blockProposedForkJSON struct {
-	inner any
+	inner BlockProposedFork
 }
 
-func (b *blockProposedForkJSON) GethType() BlockProposedFork {
+func newBlockProposedForkJSON(b BlockProposedFork) *blockProposedForkJSON {
 	if b == nil {
-		log.Warn("missing blockProposedForkJSON when converting to GethType")
 		return nil
 	}
-	switch inner := b.inner.(type) {
-	case *gaikoTypes.BlockProposed:
-		return NewHeklaBlockProposed(inner.GethType())
-	case *gaikoTypes.BlockProposedV2:
-		return NewOntakeBlockProposed(inner.GethType())
-	case *gaikoTypes.BatchProposed:
-		return NewPacayaBlockProposed(inner.GethType())
-	case *NotingBlockProposed:
-		return &NotingBlockProposed{}
-	default:
+	return &blockProposedForkJSON{b}
+}
+
+func (b *blockProposedForkJSON) GethType() BlockProposedFork {
+	if b == nil {
+		log.Warn("missing blockProposedForkJSON when converting to GethType")
 		return nil
 	}
+	return b.inner
 }
 
 func (b *blockProposedForkJSON) UnmarshalJSON(data []byte) error {
 	raw := map[string]json.RawMessage{}
-	if err := json.Unmarshal(data, &raw); err != nil {
+	// unit variants like `Nothing` are serialized as a bare string
+	var unit string
+	if err := json.Unmarshal(data, &unit); err == nil {
+		raw[unit] = nil
+	} else if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
 	for key, val := range raw {
-		switch key {
-		case "Hekla":
-			var inner gaikoTypes.BlockProposed
-			if err := json.Unmarshal(val, &inner); err != nil {
-				return err
-			}
-			b.inner = &inner
-		case "Ontake":
-			var inner gaikoTypes.BlockProposedV2
-			if err := json.Unmarshal(val, &inner); err != nil {
-				return err
-			}
-			b.inner = &inner
-		case "Pacaya":
-			var inner gaikoTypes.BatchProposed
-			if err := json.Unmarshal(val, &inner); err != nil {
-				return err
-			}
-			b.inner = &inner
-		case "Nothing":
-			b.inner = &NotingBlockProposed{}
-		default:
+		fork, err := lookupHardFork(key)
+		if err != nil || fork.decodeProposed == nil {
 			return fmt.Errorf("unknown BlockProposedFork type: %s", key)
 		}
+		if b.inner, err = fork.decodeProposed(val); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+func (b *blockProposedForkJSON) MarshalJSON() ([]byte, error) {
+	fork, err := lookupHardFork(b.inner.HardFork())
+	if err != nil || fork.decodeProposed == nil {
+		return nil, fmt.Errorf("unknown BlockProposedFork type: %s", b.inner.HardFork())
+	}
+	if fork.encodeProposed == nil {
+		return json.Marshal(fork.name)
+	}
+	inner, err := fork.encodeProposed(b.inner)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]any{fork.name: inner})
+}
+
+func (s *StorageEntry) MarshalJSON() ([]byte, error) {
+	slots := make([]*math.HexOrDecimal256, len(s.Slots))
+	for i, slot := range s.Slots {
+		slots[i] = (*math.HexOrDecimal256)(slot)
+	}
+	return json.Marshal([]any{s.Trie, slots})
+}
+
 func (s *StorageEntry) UnmarshalJSON(data []byte) error {
 	var raw [2]json.RawMessage
 	if err := json.Unmarshal(data, &raw); err != nil {
@@ -163,7 +253,18 @@ func (g *GuestInput) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &dec); err != nil {
 		return err
 	}
+	if err := dec.migrate(dec.SchemaVersion); err != nil {
+		return err
+	}
 
 	*g = *dec.GethType()
 	return nil
 }
+
+func (g *GuestInput) MarshalJSON() ([]byte, error) {
+	enc, err := newGuestInputJSON(g)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(enc)
+}
@@ -0,0 +1,82 @@
+package witness
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// hardFork bundles everything gaiko needs to know about a taiko hardfork:
+// how raiko serializes its proposal event, how the proven metadata and
+// transition are rebuilt from a witness and how the signed public input is
+// encoded.
+//
+// Every fork registers itself from its own `fork_<name>.go` file, so adding a
+// fork does not touch the decoding or proving code paths.
+type hardFork struct {
+	// name is the variant name of `BlockProposedFork` in raiko, e.g. "Pacaya".
+	name string
+	// decodeProposed decodes the payload of the `BlockProposedFork` variant,
+	// nil if raiko never sends the fork as a variant.
+	decodeProposed func(data []byte) (BlockProposedFork, error)
+	// proposedType is the raiko type of the payload, the strict validation
+	// checks the payload against it. It's nil for unit variants.
+	proposedType reflect.Type
+	// encodeProposed returns the payload of the `BlockProposedFork` variant,
+	// nil for unit variants which are serialized as the bare fork name.
+	encodeProposed func(b BlockProposedFork) (any, error)
+	// block supports proving a single block, nil if the fork is batch only.
+	block *forkProving[*GuestInput]
+	// batch supports proving a batch of blocks, nil if the fork is block only.
+	batch *forkProving[*BatchGuestInput]
+	// encodePublicInput abi encodes the public input to be signed.
+	encodePublicInput func(p *PublicInput, metaHash common.Hash) ([]byte, error)
+}
+
+// forkProving rebuilds the proven data of a witness input.
+type forkProving[T WitnessInput] struct {
+	metadata   func(input T) (BlockMetadataFork, error)
+	transition func(input T) (any, error)
+}
+
+var hardForks = map[string]*hardFork{}
+
+// registerHardFork makes a hardfork available to the decoders and provers,
+// it panics if the same fork is registered twice.
+func registerHardFork(fork *hardFork) {
+	if _, ok := hardForks[fork.name]; ok {
+		panic(fmt.Sprintf("duplicate hardfork: %s", fork.name))
+	}
+	hardForks[fork.name] = fork
+}
+
+func lookupHardFork(name string) (*hardFork, error) {
+	fork, ok := hardForks[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hardfork: %s", name)
+	}
+	return fork, nil
+}
+
+func lookupBlockHardFork(name string) (*forkProving[*GuestInput], error) {
+	fork, err := lookupHardFork(name)
+	if err != nil {
+		return nil, err
+	}
+	if fork.block == nil {
+		return nil, fmt.Errorf("hardfork %s does not support block proving", name)
+	}
+	return fork.block, nil
+}
+
+func lookupBatchHardFork(name string) (*forkProving[*BatchGuestInput], error) {
+	fork, err := lookupHardFork(name)
+	if err != nil {
+		return nil, err
+	}
+	if fork.batch == nil {
+		return nil, fmt.Errorf("hardfork %s does not support batch proving", name)
+	}
+	return fork.batch, nil
+}
@@ -0,0 +1,150 @@
+package witness
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gaikoTypes "github.com/taikoxyz/gaiko/internal/types"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+)
+
+func TestHardForkRegistry(t *testing.T) {
+	for _, name := range []string{
+		NothingHardFork,
+		HeklaHardFork,
+		OntakeHardFork,
+		PacayaHardFork,
+		ShastaHardFork,
+	} {
+		fork, err := lookupHardFork(name)
+		require.NoError(t, err, name)
+		assert.NotNil(t, fork.decodeProposed, name)
+		assert.NotNil(t, fork.encodePublicInput, name)
+		assert.True(t, fork.block != nil || fork.batch != nil, name)
+	}
+
+	_, err := lookupHardFork("Unknown")
+	assert.Error(t, err)
+	_, err = lookupBlockHardFork(ShastaHardFork)
+	assert.Error(t, err)
+	_, err = lookupBatchHardFork(OntakeHardFork)
+	assert.Error(t, err)
+}
+
+func TestShastaProposedDecode(t *testing.T) {
+	fork, err := lookupHardFork(ShastaHardFork)
+	require.NoError(t, err)
+	proposed, err := fork.decodeProposed([]byte(`{
+		"proposal": {
+			"id": 7,
+			"timestamp": "0x6553f100",
+			"endOfSubmissionWindowTimestamp": 0,
+			"proposer": "0x0000000000000000000000000000000000000001",
+			"coreStateHash": "0x0000000000000000000000000000000000000000000000000000000000000002",
+			"derivationHash": "0x0000000000000000000000000000000000000000000000000000000000000003"
+		},
+		"derivation": {
+			"originBlockNumber": 100,
+			"originBlockHash": "0x0000000000000000000000000000000000000000000000000000000000000004",
+			"isForcedInclusion": false,
+			"basefeeSharingPctg": 75,
+			"blobSlice": {
+				"blobHashes": ["0x0000000000000000000000000000000000000000000000000000000000000005"],
+				"offset": 16,
+				"timestamp": 0
+			}
+		},
+		"parentTransitionHash": "0x0000000000000000000000000000000000000000000000000000000000000006",
+		"designatedProver": "0x0000000000000000000000000000000000000007"
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, ShastaHardFork, proposed.HardFork())
+	assert.Equal(t, uint64(0x6553f100), proposed.BlockTimestamp())
+	assert.Equal(t, uint64(100), proposed.ProposedIn())
+	assert.Equal(t, uint32(16), proposed.BlobTxListOffset())
+	assert.True(t, proposed.BlobUsed())
+
+	encoded, err := proposed.ABIEncode()
+	require.NoError(t, err)
+	assert.NotEmpty(t, encoded)
+
+	// the expected hashes are keccak256 of the abi encodings written out word
+	// by word
+	proposalHash := common.HexToHash("0xdc6e6747f7dd16b8277364db39e798f4a2e66a962208a80114196e6b832fcd3b")
+	assert.Equal(t, proposalHash, proposed.BlockMetadataFork().Hash())
+
+	shasta := proposed.(*ShastaBlockProposed)
+	data, err := shastaDerivationComponentsArgs.Pack(&shasta.Derivation)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		common.HexToHash("0x949c4fb3803207338ebe252bfba3f56dc4dc08b0e905dd3e6b227da5fc2accf6"),
+		keccak.Keccak(data),
+	)
+
+	pi := &PublicInput{
+		transition: &gaikoTypes.IInboxTransition{
+			ProposalHash:         proposalHash,
+			ParentTransitionHash: shasta.ParentTransitionHash,
+			Checkpoint: gaikoTypes.ICheckpointStoreCheckpoint{
+				BlockNumber: big.NewInt(42),
+				BlockHash:   common.HexToHash("0x09"),
+				StateRoot:   common.HexToHash("0x0a"),
+			},
+			DesignatedProver: shasta.DesignatedProver,
+			ActualProver:     common.HexToAddress("0x0b"),
+		},
+		block_metadata: proposed.BlockMetadataFork(),
+		verifier:       common.HexToAddress("0x08"),
+		sgxInstance:    common.HexToAddress("0x0c"),
+		chainID:        167000,
+		fork:           fork,
+	}
+	hash, err := pi.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x5fd424e7b2dfdc2064a54ddad8433ebe9f26d4d8c35e4e6f64c54801f82862f8"), hash)
+}
+
+func TestBlockProposedForkUnitVariant(t *testing.T) {
+	var b blockProposedForkJSON
+	require.NoError(t, b.UnmarshalJSON([]byte(`"Nothing"`)))
+	assert.Equal(t, NothingHardFork, b.GethType().HardFork())
+
+	// the L1 fork isn't a raiko variant
+	assert.Error(t, b.UnmarshalJSON([]byte(`"L1"`)))
+	assert.Error(t, b.UnmarshalJSON([]byte(`{"L1": null}`)))
+}
+
+func TestL1ChainSpecs(t *testing.T) {
+	for _, spec := range defaultSupportedChainSpecs {
+		if spec.IsTaiko {
+			continue
+		}
+		chainConfig, err := spec.chainConfig()
+		require.NoError(t, err, spec.Name)
+		assert.Equal(t, spec.ChainID, chainConfig.ChainID.Uint64(), spec.Name)
+		assert.NotNil(t, chainConfig.PragueTime, spec.Name)
+	}
+}
+
+func TestEmptyBatch(t *testing.T) {
+	chainSpec, err := LookupChainSpec(TaikoHoodiNetwork)
+	require.NoError(t, err)
+	for _, proposed := range []BlockProposedFork{
+		NewPacayaBlockProposed(&pacaya.TaikoInboxClientBatchProposed{}),
+		&ShastaBlockProposed{},
+	} {
+		input := &BatchGuestInput{Taiko: &TaikoGuestBatchInput{
+			BatchProposed: proposed,
+			ChainSpec:     chainSpec,
+		}}
+		for _, sgxType := range []string{"", "debug"} {
+			_, err := NewPublicInput(input, SGXGethProofType, sgxType, common.Address{})
+			assert.ErrorIs(t, err, errNoInputs, proposed.HardFork())
+		}
+	}
+}
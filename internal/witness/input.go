@@ -1,6 +1,7 @@
 package witness
 
 import (
+	"io"
 	"iter"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -18,6 +19,12 @@ type ID struct {
 	BlockID uint64
 }
 
+// StreamDecoder is implemented by the witnesses that can be decoded from a
+// reader without holding the whole JSON document.
+type StreamDecoder interface {
+	DecodeStream(r io.Reader) error
+}
+
 // WitnessInput is an interface for witnesses.
 type WitnessInput interface {
 	// GuestInputs returns a sequence of pairs of GuestInput and Transactions.
@@ -29,7 +36,7 @@ type WitnessInput interface {
 	// Verify verifies the witness.
 	Verify(proofType ProofType) error
 	// Transition returns the transition data.
-	Transition() any
+	Transition() (any, error)
 	// ForkVerifierAddress returns the verifier address.
 	ForkVerifierAddress(proofType ProofType) common.Address
 	// Prover returns the prover address.
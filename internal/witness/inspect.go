@@ -0,0 +1,186 @@
+package witness
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/gaiko/pkg/mpt"
+)
+
+// Summary describes a witness without executing it.
+type Summary struct {
+	Chain           Network            `json:"chain"`
+	ChainID         uint64             `json:"chain_id"`
+	HardFork        string             `json:"hard_fork"`
+	BatchID         uint64             `json:"batch_id,omitempty"`
+	Blocks          []*BlockSummary    `json:"blocks"`
+	Blobs           []*BlobSummary     `json:"blobs"`
+	CalldataSize    int                `json:"calldata_size"`
+	Accounts        []*AccountSummary  `json:"heaviest_accounts"`
+	Contracts       []*ContractSummary `json:"contracts"`
+	AncestorHeaders HeaderRange        `json:"ancestor_headers"`
+}
+
+type BlockSummary struct {
+	Number     uint64      `json:"number"`
+	Hash       common.Hash `json:"hash"`
+	BodyTxs    int         `json:"body_txs"`
+	DecodedTxs int         `json:"decoded_txs"`
+	StateTrie  TrieSize    `json:"state_trie"`
+	Storage    TrieSize    `json:"storage_tries"`
+}
+
+type BlobSummary struct {
+	Size     int `json:"size"`
+	DataSize int `json:"data_size"`
+}
+
+type AccountSummary struct {
+	Block   uint64         `json:"block"`
+	Address common.Address `json:"address"`
+	Slots   int            `json:"slots"`
+	Trie    TrieSize       `json:"trie"`
+}
+
+type ContractSummary struct {
+	Hash common.Hash `json:"hash"`
+	Size int         `json:"size"`
+}
+
+// TrieSize is the number of nodes of a trie and the size of their encoding.
+type TrieSize struct {
+	Nodes int `json:"nodes"`
+	Bytes int `json:"bytes"`
+}
+
+type HeaderRange struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// Inspect summarizes a witness, listing the top storage tries by size.
+func Inspect(input WitnessInput, top int) (*Summary, error) {
+	if top < 0 {
+		return nil, fmt.Errorf("negative number of accounts: %d", top)
+	}
+	summary := &Summary{
+		ChainID:  input.ChainID(),
+		HardFork: input.BlockProposedFork().HardFork(),
+		BatchID:  input.ID().BatchID,
+	}
+	switch input := input.(type) {
+	case *GuestInput:
+		summary.Chain = input.ChainSpec.Name
+		if input.Taiko.BlockProposed.BlobUsed() {
+			summary.Blobs = append(summary.Blobs, newBlobSummary(input.Taiko.TxData))
+		} else {
+			summary.CalldataSize = len(input.Taiko.TxData)
+		}
+	case *BatchGuestInput:
+		summary.Chain = input.Taiko.ChainSpec.Name
+		for _, blob := range input.Taiko.TxDataFromBlob {
+			summary.Blobs = append(summary.Blobs, newBlobSummary(blob[:]))
+		}
+		summary.CalldataSize = len(input.Taiko.TxDataFromCalldata)
+	default:
+		return nil, fmt.Errorf("unsupported witness type: %T", input)
+	}
+
+	var (
+		contracts = map[common.Hash]int{}
+		ancestors []uint64
+	)
+	for pair := range input.GuestInputs() {
+		block, err := inspectBlock(pair, summary)
+		if err != nil {
+			return nil, err
+		}
+		summary.Blocks = append(summary.Blocks, block)
+		for _, code := range pair.Input.Contracts {
+			contracts[keccak.Keccak(code)] = len(code)
+		}
+		for _, header := range pair.Input.AncestorHeaders {
+			ancestors = append(ancestors, header.Number.Uint64())
+		}
+	}
+	for hash, size := range contracts {
+		summary.Contracts = append(summary.Contracts, &ContractSummary{Hash: hash, Size: size})
+	}
+	slices.SortFunc(summary.Contracts, func(a, b *ContractSummary) int {
+		return cmp.Or(cmp.Compare(b.Size, a.Size), a.Hash.Cmp(b.Hash))
+	})
+	slices.SortFunc(summary.Accounts, func(a, b *AccountSummary) int {
+		return cmp.Or(
+			cmp.Compare(b.Trie.Bytes, a.Trie.Bytes),
+			cmp.Compare(a.Block, b.Block),
+			a.Address.Cmp(b.Address),
+		)
+	})
+	summary.Accounts = summary.Accounts[:min(top, len(summary.Accounts))]
+	if len(ancestors) != 0 {
+		summary.AncestorHeaders = HeaderRange{From: slices.Min(ancestors), To: slices.Max(ancestors)}
+	}
+	return summary, nil
+}
+
+func inspectBlock(pair *Pair, summary *Summary) (*BlockSummary, error) {
+	g := pair.Input
+	block := &BlockSummary{
+		Number:     g.Block.NumberU64(),
+		Hash:       g.Block.Hash(),
+		BodyTxs:    g.Block.Transactions().Len(),
+		DecodedTxs: pair.Txs.Len(),
+	}
+	if g.ExecutionWitness != nil {
+		for _, node := range g.ExecutionWitness.State {
+			block.StateTrie.Nodes++
+			block.StateTrie.Bytes += len(node)
+		}
+	} else {
+		var err error
+		if block.StateTrie, err = trieSize(g.ParentStateTrie); err != nil {
+			return nil, err
+		}
+	}
+	for addr, entry := range g.ParentStorage {
+		size, err := trieSize(entry.Trie)
+		if err != nil {
+			return nil, err
+		}
+		block.Storage.Nodes += size.Nodes
+		block.Storage.Bytes += size.Bytes
+		summary.Accounts = append(summary.Accounts, &AccountSummary{
+			Block:   block.Number,
+			Address: addr,
+			Slots:   len(entry.Slots),
+			Trie:    size,
+		})
+	}
+	return block, nil
+}
+
+func trieSize(trie *mpt.MptNode) (TrieSize, error) {
+	var size TrieSize
+	if trie == nil {
+		return size, nil
+	}
+	_, err := trie.Hash(func(node []byte) {
+		size.Nodes++
+		size.Bytes += len(node)
+	})
+	return size, err
+}
+
+func newBlobSummary(blob []byte) *BlobSummary {
+	summary := &BlobSummary{Size: len(blob)}
+	if len(blob) == eth.BlobSize {
+		if data, err := eth.Blob(blob).ToData(); err == nil {
+			summary.DataSize = len(data)
+		}
+	}
+	return summary
+}
@@ -0,0 +1,101 @@
+package witness
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var _ WitnessInput = (*L1BlockGuestInput)(nil)
+
+// L1BlockGuestInput proves a plain Ethereum block, it shares the raiko format
+// with GuestInput but there is no anchor transaction and no proposal.
+type L1BlockGuestInput struct {
+	GuestInput
+}
+
+func (g *L1BlockGuestInput) GuestInputs() iter.Seq[*Pair] {
+	return func(yield func(*Pair) bool) {
+		yield(&Pair{&g.GuestInput, g.Block.Transactions()})
+	}
+}
+
+func (g *L1BlockGuestInput) BlockProposedFork() BlockProposedFork {
+	return &L1BlockProposed{}
+}
+
+func (g *L1BlockGuestInput) Verify(_ ProofType) error {
+	if err := defaultSupportedChainSpecs.verifyChainSpec(g.ChainSpec); err != nil {
+		return err
+	}
+	if g.IsTaiko() {
+		return fmt.Errorf("unexpected taiko chain spec: %s", g.ChainSpec.Name)
+	}
+	if g.Taiko != nil {
+		if g.Taiko.AnchorTx != nil {
+			return errors.New("unexpected anchor tx in L1 block")
+		}
+		if fork := g.Taiko.BlockProposed; fork != nil && fork.HardFork() != NothingHardFork {
+			return fmt.Errorf("unexpected block proposed in L1 block: %s", fork.HardFork())
+		}
+	}
+	if g.ParentHeader.Hash() != g.Block.ParentHash() {
+		return fmt.Errorf(
+			"parent hash mismatch: expected %#x, got %#x",
+			g.ParentHeader.Hash(),
+			g.Block.ParentHash(),
+		)
+	}
+	// the state transition is checked by execution, the body must match the
+	// header as well since the block hash is what we sign.
+	txHash := types.DeriveSha(g.Block.Transactions(), trie.NewStackTrie(nil))
+	if txHash != g.Block.TxHash() {
+		return fmt.Errorf(
+			"transactions root mismatch: expected %#x, got %#x",
+			g.Block.TxHash(),
+			txHash,
+		)
+	}
+	if withdrawalsHash := g.Block.Header().WithdrawalsHash; withdrawalsHash != nil {
+		got := types.DeriveSha(g.Block.Withdrawals(), trie.NewStackTrie(nil))
+		if got != *withdrawalsHash {
+			return fmt.Errorf(
+				"withdrawals root mismatch: expected %#x, got %#x",
+				*withdrawalsHash,
+				got,
+			)
+		}
+	}
+	return nil
+}
+
+func (g *L1BlockGuestInput) BlockMetadataFork() (BlockMetadataFork, error) {
+	fork, err := lookupBlockHardFork(L1HardFork)
+	if err != nil {
+		return nil, err
+	}
+	return fork.metadata(&g.GuestInput)
+}
+
+func (g *L1BlockGuestInput) Transition() (any, error) {
+	fork, err := lookupBlockHardFork(L1HardFork)
+	if err != nil {
+		return nil, err
+	}
+	return fork.transition(&g.GuestInput)
+}
+
+func (g *L1BlockGuestInput) ForkVerifierAddress(proofType ProofType) common.Address {
+	return g.ChainSpec.getForkVerifierAddress(g.Block.NumberU64(), proofType)
+}
+
+func (g *L1BlockGuestInput) Prover() common.Address {
+	if g.Taiko == nil || g.Taiko.ProverData == nil {
+		return common.Address{}
+	}
+	return g.Taiko.ProverData.Prover
+}
@@ -6,8 +6,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/taikoxyz/gaiko/pkg/keccak"
-	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
-	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
 )
 
 type PublicInput struct {
@@ -17,38 +15,12 @@ type PublicInput struct {
 	prover         common.Address
 	sgxInstance    common.Address
 	chainID        uint64
+	fork           *hardFork
 }
 
 func (p *PublicInput) Hash() (common.Hash, error) {
-	var (
-		data []byte
-		err  error
-	)
 	metaHash := p.block_metadata.Hash()
-	switch transition := p.transition.(type) {
-	case *ontake.TaikoDataTransition:
-		data, err = publicInputsV1Type.Pack(
-			"VERIFY_PROOF",
-			p.chainID,
-			p.verifier,
-			transition,
-			p.sgxInstance,
-			p.prover,
-			metaHash,
-		)
-	case *pacaya.ITaikoInboxTransition:
-		data, err = publicInputsV2Type.Pack(
-			"VERIFY_PROOF",
-			p.chainID,
-			p.verifier,
-			transition,
-			p.sgxInstance,
-			metaHash,
-		)
-	default:
-		return common.Hash{}, fmt.Errorf("unsupported transition type: %T", transition)
-	}
-
+	data, err := p.fork.encodePublicInput(p, metaHash)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -69,18 +41,27 @@ func NewPublicInput(
 		}
 	}
 
+	fork, err := lookupHardFork(input.BlockProposedFork().HardFork())
+	if err != nil {
+		return nil, err
+	}
 	meta, err := input.BlockMetadataFork()
 	if err != nil {
 		return nil, err
 	}
+	transition, err := input.Transition()
+	if err != nil {
+		return nil, err
+	}
 
 	pi := &PublicInput{
-		transition:     input.Transition(),
+		transition:     transition,
 		block_metadata: meta,
 		verifier:       verifier,
 		prover:         input.Prover(),
 		sgxInstance:    sgxInstance,
 		chainID:        input.ChainID(),
+		fork:           fork,
 	}
 
 	if input.IsTaiko() && input.BlockProposedFork().BlockMetadataFork() != nil {
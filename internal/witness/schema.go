@@ -0,0 +1,325 @@
+package witness
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SchemaVersion is the version of the raiko JSON format of a witness. A
+// witness carries it in `schema_version`, otherwise it's inferred from the
+// fields that are present.
+//
+// The only change of the format so far is `blob_proof_type`, a field renamed
+// or dropped by raiko gets a new version with a migration below. Hekla
+// proposals need none, they keep decoding through their fork.
+type SchemaVersion uint
+
+const (
+	// SchemaV1 is the format before `blob_proof_type` was added.
+	SchemaV1 SchemaVersion = 1
+	// SchemaV2 adds `blob_proof_type` to the taiko inputs.
+	SchemaV2 SchemaVersion = 2
+
+	CurrentSchemaVersion = SchemaV2
+)
+
+// guestInputMigrations upgrade a decoded input from the version of their
+// index to the next one.
+var guestInputMigrations = map[SchemaVersion]func(*guestInputJSON){
+	SchemaV1: func(g *guestInputJSON) {
+		if g.Taiko != nil && g.Taiko.BlobProofType == "" {
+			g.Taiko.BlobProofType = KzgVersionedHash
+		}
+	},
+}
+
+var batchInputMigrations = map[SchemaVersion]func(*taikoGuestBatchInputJSON){
+	SchemaV1: func(t *taikoGuestBatchInputJSON) {
+		if t.BlobProofType == "" {
+			t.BlobProofType = KzgVersionedHash
+		}
+	},
+}
+
+func checkSchemaVersion(v SchemaVersion) error {
+	if v < SchemaV1 || v > CurrentSchemaVersion {
+		return fmt.Errorf("unsupported schema version: %d", v)
+	}
+	return nil
+}
+
+// migrate upgrades g from version v, or from the version inferred from its
+// fields if v is zero.
+func (g *guestInputJSON) migrate(v SchemaVersion) error {
+	if v == 0 {
+		v = CurrentSchemaVersion
+		if g.Taiko != nil && g.Taiko.BlobProofType == "" {
+			v = SchemaV1
+		}
+	}
+	if err := checkSchemaVersion(v); err != nil {
+		return err
+	}
+	for ; v < CurrentSchemaVersion; v++ {
+		if migrate := guestInputMigrations[v]; migrate != nil {
+			migrate(g)
+		}
+	}
+	return nil
+}
+
+func (t *taikoGuestBatchInputJSON) migrate(v SchemaVersion) error {
+	if v == 0 {
+		v = CurrentSchemaVersion
+		if t.BlobProofType == "" {
+			v = SchemaV1
+		}
+	}
+	if err := checkSchemaVersion(v); err != nil {
+		return err
+	}
+	for ; v < CurrentSchemaVersion; v++ {
+		if migrate := batchInputMigrations[v]; migrate != nil {
+			migrate(t)
+		}
+	}
+	return nil
+}
+
+// Violation is a place where a witness doesn't follow its schema.
+type Violation struct {
+	// Path locates the violation by the JSON field names of the witness.
+	Path string
+	Desc string
+}
+
+// SchemaError lists all the violations found by Validate.
+type SchemaError struct {
+	Violations []*Violation
+}
+
+func (e *SchemaError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d schema violations:", len(e.Violations))
+	for _, v := range e.Violations {
+		fmt.Fprintf(&b, "\n  %s: %s", v.Path, v.Desc)
+	}
+	return b.String()
+}
+
+// Validate checks the JSON of a witness strictly: every field must be known
+// and every required field of its schema version present. It returns a
+// *SchemaError with all the violations.
+func Validate(data []byte, input WitnessInput) error {
+	var typ reflect.Type
+	switch input.(type) {
+	case *GuestInput, *L1BlockGuestInput:
+		typ = reflect.TypeFor[guestInputJSON]()
+	case *BatchGuestInput:
+		typ = reflect.TypeFor[batchGuestInputJSON]()
+	default:
+		return fmt.Errorf("no schema for %T", input)
+	}
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return &SchemaError{[]*Violation{{Path: "$", Desc: err.Error()}}}
+	}
+	v := &validator{}
+	if raw, ok := top["schema_version"]; ok {
+		if err := json.Unmarshal(raw, &v.version); err != nil {
+			v.add("schema_version", err.Error())
+		} else if err := checkSchemaVersion(v.version); err != nil {
+			v.add("schema_version", err.Error())
+		}
+	} else {
+		v.version = inferSchemaVersion(top)
+	}
+	v.check("", typ, data)
+	if len(v.violations) != 0 {
+		return &SchemaError{v.violations}
+	}
+	return nil
+}
+
+func inferSchemaVersion(top map[string]json.RawMessage) SchemaVersion {
+	var taiko map[string]json.RawMessage
+	if raw, ok := top["taiko"]; ok && json.Unmarshal(raw, &taiko) == nil && taiko != nil {
+		if _, ok := taiko["blob_proof_type"]; !ok {
+			return SchemaV1
+		}
+	}
+	return CurrentSchemaVersion
+}
+
+type validator struct {
+	version    SchemaVersion
+	violations []*Violation
+}
+
+func (v *validator) add(path, desc string) {
+	v.violations = append(v.violations, &Violation{Path: path, Desc: desc})
+}
+
+// check walks raw along typ. Types that have no JSON fields of their own,
+// like the enums and tries with a custom encoding, aren't looked into, apart
+// from the proposals which are checked by fork.
+func (v *validator) check(path string, typ reflect.Type, raw json.RawMessage) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return
+	}
+	if typ == reflect.TypeFor[blockProposedForkJSON]() {
+		v.checkProposed(path, raw)
+		return
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		fields := schemaFields(typ)
+		if len(fields) == 0 || raw[0] != '{' {
+			return
+		}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			v.add(path, err.Error())
+			return
+		}
+		for _, name := range slices.Sorted(maps.Keys(obj)) {
+			field, ok := fields[name]
+			if !ok {
+				v.add(joinPath(path, name), "unknown field")
+				continue
+			}
+			v.check(joinPath(path, name), field.typ, obj[name])
+		}
+		for _, name := range slices.Sorted(maps.Keys(fields)) {
+			field := fields[name]
+			if _, ok := obj[name]; !ok && field.required && v.version >= field.since {
+				v.add(joinPath(path, name), "missing required field")
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 || raw[0] != '[' {
+			return
+		}
+		var list []json.RawMessage
+		if err := json.Unmarshal(raw, &list); err != nil {
+			v.add(path, err.Error())
+			return
+		}
+		for i, elem := range list {
+			v.check(path+"["+strconv.Itoa(i)+"]", typ.Elem(), elem)
+		}
+	case reflect.Map:
+		if raw[0] != '{' {
+			return
+		}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			v.add(path, err.Error())
+			return
+		}
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			v.check(path+"["+key+"]", typ.Elem(), obj[key])
+		}
+	}
+}
+
+// checkProposed checks a BlockProposedFork, the variant is looked up by name
+// and its payload checked against the raiko type of the fork.
+func (v *validator) checkProposed(path string, raw json.RawMessage) {
+	if raw[0] == '"' {
+		var name string
+		if err := json.Unmarshal(raw, &name); err != nil {
+			v.add(path, err.Error())
+			return
+		}
+		if fork, err := lookupHardFork(name); err != nil || fork.decodeProposed == nil || fork.proposedType != nil {
+			v.add(path, fmt.Sprintf("unknown unit variant: %s", name))
+		}
+		return
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		v.add(path, err.Error())
+		return
+	}
+	if len(obj) != 1 {
+		v.add(path, fmt.Sprintf("expected a single variant, got %d", len(obj)))
+	}
+	for _, name := range slices.Sorted(maps.Keys(obj)) {
+		fork, err := lookupHardFork(name)
+		if err != nil || fork.decodeProposed == nil {
+			v.add(joinPath(path, name), "unknown variant")
+			continue
+		}
+		if fork.proposedType == nil {
+			// a unit variant carries nothing
+			if payload := bytes.TrimSpace(obj[name]); string(payload) != "null" {
+				v.add(joinPath(path, name), "unexpected payload of a unit variant")
+			}
+			continue
+		}
+		v.check(joinPath(path, name), fork.proposedType, obj[name])
+	}
+}
+
+type schemaField struct {
+	typ      reflect.Type
+	required bool
+	// since is the schema version that introduced the field.
+	since SchemaVersion
+}
+
+// schemaFields returns the JSON fields of a struct. The fields of gencodec
+// types are required if tagged so, those of the other types unless they are
+// omitempty.
+func schemaFields(typ reflect.Type) map[string]*schemaField {
+	gencodec := false
+	for i := range typ.NumField() {
+		if _, ok := typ.Field(i).Tag.Lookup("gencodec"); ok {
+			gencodec = true
+		}
+	}
+	fields := map[string]*schemaField{}
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		tag, ok := f.Tag.Lookup("json")
+		if !ok || !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		field := &schemaField{typ: f.Type, since: SchemaV1}
+		if gencodec {
+			field.required = f.Tag.Get("gencodec") == "required"
+		} else {
+			field.required = !slices.Contains(strings.Split(opts, ","), "omitempty")
+		}
+		if since, err := strconv.ParseUint(f.Tag.Get("since"), 10, 0); err == nil {
+			field.since = SchemaVersion(since)
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
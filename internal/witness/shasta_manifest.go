@@ -0,0 +1,126 @@
+package witness
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// shastaManifestVersion is the version word in front of the manifest of a
+// Shasta proposal.
+const shastaManifestVersion = 1
+
+// shastaBlockManifest is a block of a Shasta proposal as its proposer
+// submitted it, the anchor transaction isn't part of it.
+type shastaBlockManifest struct {
+	Timestamp         uint64
+	Coinbase          common.Address
+	AnchorBlockNumber uint64
+	GasLimit          uint64
+	Transactions      types.Transactions
+}
+
+// shastaProposalManifest is what a Shasta proposal posts in its blobs.
+type shastaProposalManifest struct {
+	ProverAuthBytes []byte
+	Blocks          []*shastaBlockManifest
+}
+
+// defaultShastaManifest is derived in place of a manifest that can't be
+// decoded: a single block with only the anchor transaction.
+func defaultShastaManifest() *shastaProposalManifest {
+	return &shastaProposalManifest{Blocks: []*shastaBlockManifest{{}}}
+}
+
+// shastaManifest returns the manifest of the proposal of g, or the default
+// manifest if the blobs don't hold a valid one.
+func shastaManifest(g *BatchGuestInput, proposed *ShastaBlockProposed) *shastaProposalManifest {
+	manifest, err := decodeShastaManifest(g.Taiko.TxDataFromBlob, proposed.BlobTxListOffset())
+	if err != nil {
+		log.Warn("Invalid shasta manifest, using the default manifest", "batchId", g.Taiko.BatchID, "err", err)
+		return defaultShastaManifest()
+	}
+	return manifest
+}
+
+// decodeShastaManifest decodes the manifest at offset in the data of the
+// blobs: a version word, a size word and size bytes of the zlib compressed RLP
+// of the manifest.
+func decodeShastaManifest(blobs [][eth.BlobSize]byte, offset uint32) (*shastaProposalManifest, error) {
+	if len(blobs) == 0 {
+		return nil, errors.New("no blobs")
+	}
+	var data []byte
+	for _, b := range blobs {
+		blob := eth.Blob(b)
+		d, err := blob.ToData()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, d...)
+	}
+	start := uint64(offset)
+	if start+64 > uint64(len(data)) {
+		return nil, fmt.Errorf("manifest offset out of range: offset=%d, size=%d", offset, len(data))
+	}
+	version := new(big.Int).SetBytes(data[start : start+32])
+	if !version.IsUint64() || version.Uint64() != shastaManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version: %s", version)
+	}
+	size := new(big.Int).SetBytes(data[start+32 : start+64])
+	start += 64
+	if !size.IsUint64() || size.Uint64() > uint64(len(data))-start {
+		return nil, fmt.Errorf("manifest size out of range: size=%s, left=%d", size, uint64(len(data))-start)
+	}
+	r, err := zlib.NewReader(bytes.NewReader(data[start : start+size.Uint64()]))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	limit := int64(len(blobs)) * blobMaxTxListBytes
+	raw, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(raw)) > limit {
+		return nil, fmt.Errorf("manifest exceeds %d bytes", limit)
+	}
+	var manifest shastaProposalManifest
+	if err := rlp.DecodeBytes(raw, &manifest); err != nil {
+		return nil, err
+	}
+	if len(manifest.Blocks) == 0 || len(manifest.Blocks) > maxBlocksPerBatch {
+		return nil, fmt.Errorf("invalid number of manifest blocks: %d", len(manifest.Blocks))
+	}
+	return &manifest, nil
+}
+
+// checkBlockBody checks that the body of a block is what executing txs
+// produces: the anchor transaction first, then the transactions of txs that
+// didn't fail validation, in their order.
+func checkBlockBody(block *types.Block, txs types.Transactions) error {
+	body := block.Transactions()
+	if len(body) == 0 || len(txs) == 0 || body[0].Hash() != txs[0].Hash() {
+		return fmt.Errorf("block %d doesn't start with its anchor transaction", block.NumberU64())
+	}
+	next := 1
+	for _, tx := range body[1:] {
+		for next < len(txs) && txs[next].Hash() != tx.Hash() {
+			next++
+		}
+		if next == len(txs) {
+			return fmt.Errorf("block %d has transaction %#x outside its tx list", block.NumberU64(), tx.Hash())
+		}
+		next++
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package witness
+
+import (
+	"bytes"
+	"compress/zlib"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func shastaManifestBlob(t *testing.T, offset int, version uint64, manifest *shastaProposalManifest) [eth.BlobSize]byte {
+	raw, err := rlp.EncodeToBytes(manifest)
+	require.NoError(t, err)
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	_, err = w.Write(raw)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	data := make([]byte, offset+64)
+	new(big.Int).SetUint64(version).FillBytes(data[offset : offset+32])
+	new(big.Int).SetUint64(uint64(compressed.Len())).FillBytes(data[offset+32 : offset+64])
+	data = append(data, compressed.Bytes()...)
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(data))
+	return blob
+}
+
+func TestDecodeShastaManifest(t *testing.T) {
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, Gas: 21_000, To: &common.Address{1}})
+	manifest := &shastaProposalManifest{
+		ProverAuthBytes: []byte{},
+		Blocks: []*shastaBlockManifest{
+			{Timestamp: 1, Coinbase: common.Address{2}, AnchorBlockNumber: 3, GasLimit: 4},
+			{Timestamp: 2, Transactions: types.Transactions{tx}},
+		},
+	}
+	blobs := [][eth.BlobSize]byte{shastaManifestBlob(t, 16, shastaManifestVersion, manifest)}
+
+	got, err := decodeShastaManifest(blobs, 16)
+	require.NoError(t, err)
+	require.Len(t, got.Blocks, 2)
+	assert.Equal(t, common.Address{2}, got.Blocks[0].Coinbase)
+	assert.Empty(t, got.Blocks[0].Transactions)
+	require.Len(t, got.Blocks[1].Transactions, 1)
+	assert.Equal(t, tx.Hash(), got.Blocks[1].Transactions[0].Hash())
+
+	_, err = decodeShastaManifest(blobs, 0)
+	assert.ErrorContains(t, err, "unsupported manifest version")
+	_, err = decodeShastaManifest(blobs, maxBlobDataSize)
+	assert.ErrorContains(t, err, "out of range")
+	_, err = decodeShastaManifest(nil, 0)
+	assert.Error(t, err)
+
+	blobs = [][eth.BlobSize]byte{shastaManifestBlob(t, 0, 2, manifest)}
+	_, err = decodeShastaManifest(blobs, 0)
+	assert.ErrorContains(t, err, "unsupported manifest version")
+}
+
+func TestCheckBlockBody(t *testing.T) {
+	anchor := types.NewTx(&types.LegacyTx{Nonce: 0})
+	txs := make(types.Transactions, 4)
+	for i := range txs {
+		txs[i] = types.NewTx(&types.LegacyTx{Nonce: uint64(i + 1)})
+	}
+	list := append(types.Transactions{anchor}, txs...)
+	block := func(txs ...*types.Transaction) *types.Block {
+		return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}).
+			WithBody(types.Body{Transactions: txs})
+	}
+
+	require.NoError(t, checkBlockBody(block(anchor), list))
+	// txs that failed validation are dropped from the body
+	require.NoError(t, checkBlockBody(block(anchor, txs[0], txs[2]), list))
+
+	assert.ErrorContains(t, checkBlockBody(block(txs[0]), list), "anchor")
+	assert.ErrorContains(t, checkBlockBody(block(), list), "anchor")
+	assert.ErrorContains(t, checkBlockBody(block(anchor, txs[2], txs[0]), list), "outside its tx list")
+	other := types.NewTx(&types.LegacyTx{Nonce: 9})
+	assert.ErrorContains(t, checkBlockBody(block(anchor, other), list), "outside its tx list")
+}
@@ -14,6 +14,14 @@ import (
 )
 
 func (g *GuestInput) NewWitness() (*stateless.Witness, error) {
+	return g.NewWitnessWithin(nil)
+}
+
+// NewWitnessWithin is NewWitness hashing the tries on the slots of sem.
+func (g *GuestInput) NewWitnessWithin(sem mpt.Semaphore) (*stateless.Witness, error) {
+	if g.ExecutionWitness != nil {
+		return g.ExecutionWitness.newWitness(g.ParentHeader)
+	}
 	wit := new(stateless.Witness)
 	// set headers
 	wit.Headers = append([]*types.Header{g.ParentHeader}, g.AncestorHeaders...)
@@ -25,7 +33,7 @@ func (g *GuestInput) NewWitness() (*stateless.Witness, error) {
 	onRLP := func(data []byte) {
 		wit.State[string(data)] = struct{}{}
 	}
-	parentRoot, err := g.ParentStateTrie.Hash(onRLP)
+	parentRoot, err := g.ParentStateTrie.HashWithin(sem, onRLP)
 	if err != nil {
 		return nil, err
 	}
@@ -37,10 +45,10 @@ func (g *GuestInput) NewWitness() (*stateless.Witness, error) {
 	for addr, storage := range g.ParentStorage {
 		acc, err := getAccount(g.ParentStateTrie, addr)
 		if err != nil {
-			log.Warn("account not found", "id", g.ID(), "address", addr, "err", err)
+			log.Warn("account not found", "block", g.Block.NumberU64(), "address", addr, "err", err)
 			acc = types.NewEmptyStateAccount()
 		}
-		root, err := storage.Trie.Hash(onRLP)
+		root, err := storage.Trie.HashWithin(sem, onRLP)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,152 @@
+// Package compress decompresses gzip and zstd streams with a cap on the
+// decompressed size.
+package compress
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	// ErrTooLarge is returned when the decompressed data exceeds the limit.
+	ErrTooLarge = errors.New("decompressed data exceeds the size limit")
+	// ErrInvalidLimit is returned when the size limit is not positive.
+	ErrInvalidLimit = errors.New("size limit must be positive")
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// NewReader returns a reader decompressing r if it starts with a gzip or zstd
+// header, otherwise r is read as it is. At most limit bytes are read out of
+// it, a limit that is not positive fails the first Read with ErrInvalidLimit.
+// The header is only looked at by the first Read.
+func NewReader(r io.Reader, limit int64) io.ReadCloser {
+	return &detectReader{r: r, limit: limit}
+}
+
+type detectReader struct {
+	r     io.Reader
+	limit int64
+	rc    io.ReadCloser
+	err   error
+}
+
+func (d *detectReader) Read(p []byte) (int, error) {
+	if d.rc == nil && d.err == nil {
+		d.rc, d.err = d.detect()
+	}
+	if d.err != nil {
+		return 0, d.err
+	}
+	return d.rc.Read(p)
+}
+
+func (d *detectReader) detect() (io.ReadCloser, error) {
+	if d.limit <= 0 {
+		return nil, ErrInvalidLimit
+	}
+	br := bufio.NewReader(d.r)
+	magic, err := br.Peek(len(zstdMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return NewEncodingReader(br, "gzip", d.limit)
+	case bytes.HasPrefix(magic, zstdMagic):
+		return NewEncodingReader(br, "zstd", d.limit)
+	}
+	return NewEncodingReader(br, "identity", d.limit)
+}
+
+func (d *detectReader) Close() error {
+	if d.rc == nil {
+		return nil
+	}
+	return d.rc.Close()
+}
+
+// NewEncodingReader returns a reader decompressing r as given by an HTTP
+// Content-Encoding. At most limit bytes are read out of it, limit must be
+// positive.
+func NewEncodingReader(r io.Reader, encoding string, limit int64) (io.ReadCloser, error) {
+	if limit <= 0 {
+		return nil, ErrInvalidLimit
+	}
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return &limitReader{r: r, n: limit}, nil
+	case "gzip", "x-gzip":
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return &limitReader{r: zr, n: limit, close: zr.Close}, nil
+	case "zstd":
+		zr, err := zstd.NewReader(
+			r,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxMemory(uint64(limit)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &limitReader{r: zr, n: limit, close: func() error {
+			zr.Close()
+			return nil
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+}
+
+// limitReader is io.LimitedReader failing with ErrTooLarge instead of
+// truncating the data.
+type limitReader struct {
+	r     io.Reader
+	n     int64
+	close func() error // nil when r needs no closing
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		// probe for data past the limit
+		var b [1]byte
+		n, err := io.ReadFull(l.r, b[:])
+		if n > 0 {
+			return 0, ErrTooLarge
+		}
+		return 0, checkSize(err)
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, checkSize(err)
+}
+
+// checkSize turns the errors of zstd about its memory limit into ErrTooLarge.
+func checkSize(err error) error {
+	if errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) {
+		return ErrTooLarge
+	}
+	return err
+}
+
+func (l *limitReader) Close() error {
+	if l.close == nil {
+		return nil
+	}
+	return l.close()
+}
@@ -0,0 +1,73 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReader(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"inputs":[]}`), 1000)
+
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, err := gw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	zw, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	zs := zw.EncodeAll(data, nil)
+
+	for name, input := range map[string][]byte{
+		"plain": data,
+		"gzip":  gz.Bytes(),
+		"zstd":  zs,
+		"empty": nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			want := data
+			if input == nil {
+				want = []byte{}
+			}
+			r := NewReader(bytes.NewReader(input), int64(len(data)))
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+			require.NoError(t, r.Close())
+
+			if name == "empty" {
+				return
+			}
+			r = NewReader(bytes.NewReader(input), int64(len(data))-1)
+			_, err = io.ReadAll(r)
+			assert.ErrorIs(t, err, ErrTooLarge)
+		})
+	}
+}
+
+func TestNewEncodingReader(t *testing.T) {
+	_, err := NewEncodingReader(bytes.NewReader(nil), "br", 1)
+	assert.Error(t, err)
+
+	r, err := NewEncodingReader(bytes.NewReader([]byte("abc")), "identity", 3)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("abc"), got)
+
+	r, err = NewEncodingReader(bytes.NewReader([]byte("abc")), "", 2)
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	_, err = NewEncodingReader(bytes.NewReader(nil), "gzip", 0)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+	_, err = io.ReadAll(NewReader(bytes.NewReader([]byte("abc")), -1))
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
@@ -0,0 +1,110 @@
+package mpt
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var _ rlp.Decoder = (*MptNode)(nil)
+var _ encoding.BinaryMarshaler = (*MptNode)(nil)
+var _ encoding.BinaryUnmarshaler = (*MptNode)(nil)
+
+// DecodeRLP decodes a single node as written by EncodeRLP, children referenced
+// by hash become digest nodes.
+func (m *MptNode) DecodeRLP(s *rlp.Stream) error {
+	kind, _, err := s.Kind()
+	if err != nil {
+		return err
+	}
+	raw, err := s.Raw()
+	if err != nil {
+		return err
+	}
+	var node *MptNode
+	if kind == rlp.List {
+		node, err = decodeNode(raw, false)
+	} else {
+		// the null node or a digest
+		node, _, err = decodeRef(raw, false)
+	}
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		node = New()
+	}
+	m.data = node.data
+	m.cachedRef.Store(nil)
+	return nil
+}
+
+// MarshalBinary encodes the whole trie as nested RLP. It is the encoding of
+// EncodeRLP, except that the children referenced by hash are inlined as well,
+// so only digest nodes are written as 32 byte strings.
+func (m *MptNode) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	w := rlp.NewEncoderBuffer(&buf)
+	if err := m.encodeBinary(w); err != nil {
+		return nil, err
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (m *MptNode) encodeBinary(w rlp.EncoderBuffer) error {
+	switch data := m.data.(type) {
+	case nil, *nullNode:
+		w.Write(rlp.EmptyString)
+	case *branchNode:
+		list := w.List()
+		for _, child := range data {
+			if child == nil {
+				w.Write(rlp.EmptyString)
+			} else if err := child.encodeBinary(w); err != nil {
+				return err
+			}
+		}
+		w.Write(rlp.EmptyString)
+		w.ListEnd(list)
+	case *leafNode:
+		list := w.List()
+		w.WriteBytes(data.prefix)
+		w.WriteBytes(data.value)
+		w.ListEnd(list)
+	case *extensionNode:
+		list := w.List()
+		w.WriteBytes(data.prefix)
+		if err := data.child.encodeBinary(w); err != nil {
+			return err
+		}
+		w.ListEnd(list)
+	case *digestNode:
+		w.WriteBytes(data[:])
+	default:
+		return fmt.Errorf("unknown MptNodeData type: %T", data)
+	}
+	return nil
+}
+
+// UnmarshalBinary decodes a trie written by MarshalBinary.
+func (m *MptNode) UnmarshalBinary(data []byte) error {
+	node, rest, err := decodeRef(data, true)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return errors.New("trailing data after trie")
+	}
+	if node == nil {
+		node = New()
+	}
+	m.data = node.data
+	m.cachedRef.Store(nil)
+	return nil
+}
@@ -0,0 +1,242 @@
+package mpt
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DiffKind is the kind of a Difference.
+type DiffKind uint8
+
+const (
+	// DiffAdded is a key only present in the second trie.
+	DiffAdded DiffKind = iota
+	// DiffRemoved is a key only present in the first trie.
+	DiffRemoved
+	// DiffChanged is a key with different values.
+	DiffChanged
+	// DiffDigest is an equal subtrie which is a digest on one side only.
+	DiffDigest
+	// DiffUnresolved is a different subtrie which is a digest on at least one
+	// side, so its keys cannot be compared.
+	DiffUnresolved
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	case DiffChanged:
+		return "changed"
+	case DiffDigest:
+		return "digest"
+	case DiffUnresolved:
+		return "unresolved"
+	default:
+		return fmt.Sprintf("DiffKind(%d)", uint8(k))
+	}
+}
+
+// Difference is a difference between two tries found by Diff.
+type Difference struct {
+	Kind DiffKind
+	// Key is the key of an added, removed or changed leaf.
+	Key []byte
+	// A and B are the values of the key in the first and second trie.
+	A, B []byte
+	// Path is the nibble path of a digest or unresolved subtrie.
+	Path []byte
+	// HashA and HashB are the hashes of the subtrie in the first and second
+	// trie.
+	HashA, HashB common.Hash
+}
+
+func (d *Difference) String() string {
+	switch d.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("added %#x: %#x", d.Key, d.B)
+	case DiffRemoved:
+		return fmt.Sprintf("removed %#x: %#x", d.Key, d.A)
+	case DiffChanged:
+		return fmt.Sprintf("changed %#x: %#x -> %#x", d.Key, d.A, d.B)
+	default:
+		return fmt.Sprintf("%s subtrie at path %x: %#x -> %#x", d.Kind, d.Path, d.HashA, d.HashB)
+	}
+}
+
+// Diff compares two tries key by key, the keys of subtries with equal hashes
+// are not compared but digests expanded on the other side are reported. The
+// structure of the tries may differ, e.g. when a key added to b splits a
+// leaf of a, only the changed keys are reported. Digests are resolved from
+// the node store of each trie if there is one, the tries aren't changed.
+func Diff(a, b *MptNode) ([]*Difference, error) {
+	d := &differ{storeA: nodeStore(a), storeB: nodeStore(b)}
+	if err := d.diff(a, b, nil); err != nil {
+		return nil, err
+	}
+	return d.res, nil
+}
+
+type differ struct {
+	storeA NodeStore
+	storeB NodeStore
+	res    []*Difference
+}
+
+func (d *differ) diff(a, b *MptNode, path []byte) error {
+	hashA, err := nodeHash(a)
+	if err != nil {
+		return err
+	}
+	hashB, err := nodeHash(b)
+	if err != nil {
+		return err
+	}
+	if hashA == hashB {
+		d.same(a, b, hashA, path)
+		return nil
+	}
+	a, digestA := resolve(a, d.storeA)
+	b, digestB := resolve(b, d.storeB)
+	if digestA || digestB {
+		d.res = append(d.res, &Difference{
+			Kind:  DiffUnresolved,
+			Path:  slices.Clone(path),
+			HashA: hashA,
+			HashB: hashB,
+		})
+		return nil
+	}
+
+	childrenA, valueA := branchView(a)
+	childrenB, valueB := branchView(b)
+	if !bytes.Equal(valueA, valueB) {
+		diff := &Difference{Key: fromNibs(path), A: valueA, B: valueB}
+		switch {
+		case valueA == nil:
+			diff.Kind = DiffAdded
+		case valueB == nil:
+			diff.Kind = DiffRemoved
+		default:
+			diff.Kind = DiffChanged
+		}
+		d.res = append(d.res, diff)
+	}
+	for i := range childrenA {
+		if childrenA[i] == nil && childrenB[i] == nil {
+			continue
+		}
+		if err := d.diff(childrenA[i], childrenB[i], slices.Concat(path, []byte{byte(i)})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// same reports the digests of a which are expanded in b and vice versa, a and
+// b have the same hash and thus the same structure.
+func (d *differ) same(a, b *MptNode, hash common.Hash, path []byte) {
+	a, digestA := resolve(a, d.storeA)
+	b, digestB := resolve(b, d.storeB)
+	if digestA != digestB {
+		d.res = append(d.res, &Difference{
+			Kind:  DiffDigest,
+			Path:  slices.Clone(path),
+			HashA: hash,
+			HashB: hash,
+		})
+	}
+	if digestA || digestB || a == nil || b == nil {
+		return
+	}
+	switch dataA := a.data.(type) {
+	case *branchNode:
+		dataB := b.data.(*branchNode)
+		for i, child := range dataA {
+			if child == nil {
+				continue
+			}
+			ref, err := child.ref()
+			if err != nil {
+				continue
+			}
+			d.same(child, dataB[i], ref.hash(), slices.Concat(path, []byte{byte(i)}))
+		}
+	case *extensionNode:
+		dataB := b.data.(*extensionNode)
+		ref, err := dataA.child.ref()
+		if err != nil {
+			return
+		}
+		d.same(dataA.child, dataB.child, ref.hash(), slices.Concat(path, prefixNibs(dataA.prefix)))
+	}
+}
+
+// branchView returns node as a branch: its children by the next nibble and
+// the value of a leaf ending at node. The remaining path of a leaf or an
+// extension becomes a virtual child node.
+func branchView(node *MptNode) (children [16]*MptNode, value []byte) {
+	if node == nil {
+		return
+	}
+	switch data := node.data.(type) {
+	case *branchNode:
+		children = *data
+	case *leafNode:
+		nibs := prefixNibs(data.prefix)
+		if len(nibs) == 0 {
+			return children, data.value
+		}
+		children[nibs[0]] = newMptNode(&leafNode{
+			prefix: toEncodedPath(nibs[1:], true),
+			value:  data.value,
+		})
+	case *extensionNode:
+		nibs := prefixNibs(data.prefix)
+		if len(nibs) == 1 {
+			children[nibs[0]] = data.child
+		} else {
+			children[nibs[0]] = newMptNode(&extensionNode{
+				prefix: toEncodedPath(nibs[1:], false),
+				child:  data.child,
+			})
+		}
+	}
+	return
+}
+
+func nodeHash(node *MptNode) (common.Hash, error) {
+	if node == nil {
+		return types.EmptyRootHash, nil
+	}
+	return node.Hash()
+}
+
+func nodeStore(node *MptNode) NodeStore {
+	if node == nil {
+		return nil
+	}
+	return node.store
+}
+
+// resolve returns a digest resolved from store without changing the trie, it
+// reports whether the node is still a digest.
+func resolve(node *MptNode, store NodeStore) (*MptNode, bool) {
+	if node == nil || !node.IsDigest() {
+		return node, false
+	}
+	if store == nil {
+		return node, true
+	}
+	resolved, err := node.resolved(store)
+	if err != nil {
+		return node, true
+	}
+	return resolved, false
+}
@@ -0,0 +1,160 @@
+package mpt
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// parallelThreshold is the number of unhashed nodes from which Hash fans
+	// out to concurrent workers, smaller tries are hashed serially.
+	parallelThreshold = 4096
+	// parallelDepth is the number of branch levels whose children are hashed
+	// concurrently, up to 256 subtries.
+	parallelDepth = 2
+)
+
+// unhashed counts the nodes without a cached reference up to limit.
+func (m *MptNode) unhashed(limit int) int {
+	if limit <= 0 || m.cachedRef.Load() != nil {
+		return 0
+	}
+	n := 1
+	switch data := m.data.(type) {
+	case *branchNode:
+		for _, child := range data {
+			if n >= limit {
+				break
+			}
+			if child != nil {
+				n += child.unhashed(limit - n)
+			}
+		}
+	case *extensionNode:
+		n += data.child.unhashed(limit - n)
+	}
+	return n
+}
+
+// Semaphore bounds the goroutines of concurrent work. Hash only hands a subtrie
+// to another goroutine when it can take a slot without waiting, so a caller
+// holding a slot can share the semaphore with the tries it hashes.
+type Semaphore chan struct{}
+
+// NewSemaphore creates a Semaphore of n slots.
+func NewSemaphore(n int) Semaphore {
+	return make(Semaphore, n)
+}
+
+// Acquire waits for a free slot, it fails once ctx is done.
+func (s Semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryAcquire takes a slot if one is free.
+func (s Semaphore) TryAcquire() bool {
+	select {
+	case s <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release frees a slot taken by Acquire or TryAcquire.
+func (s Semaphore) Release() {
+	<-s
+}
+
+// hashParallel caches the references of the subtries below the top branches
+// on the free slots of sem besides the caller, a nil sem has GOMAXPROCS slots.
+// The result is the same as the serial path.
+func (m *MptNode) hashParallel(sem Semaphore) error {
+	if sem == nil {
+		sem = NewSemaphore(runtime.GOMAXPROCS(0))
+	}
+	h := &parallelHasher{sem: sem}
+	return h.hash(m, 0)
+}
+
+type parallelHasher struct {
+	sem Semaphore
+}
+
+// hash computes the reference of node, the children of the branches above
+// parallelDepth are handed to an idle worker if there is one.
+func (h *parallelHasher) hash(node *MptNode, depth int) error {
+	if depth >= parallelDepth || node.cachedRef.Load() != nil {
+		_, err := node.ref()
+		return err
+	}
+	switch data := node.data.(type) {
+	case *branchNode:
+		var (
+			wg   sync.WaitGroup
+			errs [16]error
+		)
+		for i, child := range data {
+			if child == nil {
+				continue
+			}
+			if !h.sem.TryAcquire() {
+				errs[i] = h.hash(child, depth+1)
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer h.sem.Release()
+				errs[i] = h.hash(child, depth+1)
+			}()
+		}
+		wg.Wait()
+		if err := errors.Join(errs[:]...); err != nil {
+			return err
+		}
+	case *extensionNode:
+		if err := h.hash(data.child, depth); err != nil {
+			return err
+		}
+	}
+	_, err := node.ref()
+	return err
+}
+
+// walk calls wlk with the RLP encoding of every node of the trie in post
+// order, null and digest nodes are not visited.
+func (m *MptNode) walk(wlk func([]byte)) error {
+	switch data := m.data.(type) {
+	case *nullNode, *digestNode:
+		return nil
+	case *branchNode:
+		for _, child := range data {
+			if child == nil {
+				continue
+			}
+			if err := child.walk(wlk); err != nil {
+				return err
+			}
+		}
+	case *extensionNode:
+		if err := data.child.walk(wlk); err != nil {
+			return err
+		}
+	}
+	encoded, err := rlp.EncodeToBytes(m)
+	if err != nil {
+		return err
+	}
+	wlk(encoded)
+	return nil
+}
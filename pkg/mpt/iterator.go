@@ -0,0 +1,107 @@
+package mpt
+
+import (
+	"bytes"
+	"iter"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// IterOptions bounds the leaves visited by MptNode.Leaves.
+type IterOptions struct {
+	// Start is the first key to visit, smaller keys are skipped.
+	Start []byte
+	// Prefix limits the iteration to the keys starting with it.
+	Prefix []byte
+	// Digest is called with the nibble path and the hash of every digest node
+	// within the bounds that cannot be resolved, its leaves are skipped.
+	Digest func(path []byte, hash common.Hash)
+}
+
+// Leaves returns an iterator over the keys and values of the trie in key
+// order. Digests are resolved from the node store of the trie if there is
+// one, without changing the trie, so that iterations may run concurrently.
+// opts may be nil to visit every leaf.
+func (m *MptNode) Leaves(opts *IterOptions) iter.Seq2[[]byte, []byte] {
+	if opts == nil {
+		opts = &IterOptions{}
+	}
+	it := &leafIterator{
+		start:    toNibs(opts.Start),
+		prefix:   toNibs(opts.Prefix),
+		onDigest: opts.Digest,
+		store:    m.store,
+	}
+	return func(yield func([]byte, []byte) bool) {
+		it.walk(m, nil, yield)
+	}
+}
+
+type leafIterator struct {
+	start    []byte
+	prefix   []byte
+	onDigest func(path []byte, hash common.Hash)
+	store    NodeStore
+}
+
+// inBounds reports whether the subtrie at path may contain keys within the
+// bounds, every key of the subtrie starts with path.
+func (it *leafIterator) inBounds(path []byte) bool {
+	n := min(len(path), len(it.prefix))
+	if !bytes.Equal(path[:n], it.prefix[:n]) {
+		return false
+	}
+	n = min(len(path), len(it.start))
+	return bytes.Compare(path, it.start[:n]) >= 0
+}
+
+// walk visits the leaves of node, it returns false once yield asks to stop.
+func (it *leafIterator) walk(node *MptNode, path []byte, yield func([]byte, []byte) bool) bool {
+	if !it.inBounds(path) {
+		return true
+	}
+	switch data := node.data.(type) {
+	case *branchNode:
+		for i, child := range data {
+			if child == nil {
+				continue
+			}
+			if !it.walk(child, slices.Concat(path, []byte{byte(i)}), yield) {
+				return false
+			}
+		}
+	case *extensionNode:
+		return it.walk(data.child, slices.Concat(path, prefixNibs(data.prefix)), yield)
+	case *leafNode:
+		key := slices.Concat(path, prefixNibs(data.prefix))
+		if !bytes.HasPrefix(key, it.prefix) || bytes.Compare(key, it.start) < 0 {
+			return true
+		}
+		return yield(fromNibs(key), data.value)
+	case *digestNode:
+		// the trie is only read, the resolved node isn't kept in it
+		if it.store != nil {
+			if resolved, err := node.resolved(it.store); err == nil {
+				return it.walk(resolved, path, yield)
+			}
+		}
+		if it.onDigest != nil {
+			it.onDigest(path, common.Hash(*data))
+		}
+	}
+	return true
+}
+
+// fromNibs packs nibbles into bytes, the inverse of toNibs.
+func fromNibs(nibs []byte) []byte {
+	res := make([]byte, 0, (len(nibs)+1)/2)
+	for c := range slices.Chunk(nibs, 2) {
+		b := c[0] << 4
+		if len(c) == 2 {
+			b |= c[1]
+		}
+		res = append(res, b)
+	}
+	return res
+}
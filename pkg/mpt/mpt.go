@@ -3,8 +3,8 @@ package mpt
 import (
 	"bytes"
 	"errors"
-	"fmt"
 	"slices"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -14,8 +14,15 @@ import (
 
 // MptNode porting from taikoxzy/raiko
 type MptNode struct {
-	data      mptNodeData
-	cachedRef mptNodeRef
+	data mptNodeData
+	// cachedRef is set by concurrent hashing, it is only reset by the
+	// mutating methods which are not safe for concurrent use.
+	cachedRef atomic.Pointer[mptNodeRef]
+	// store resolves digests reached by Get, Insert and Delete, only the
+	// store of the node the call starts from is used.
+	store NodeStore
+	// shared marks an immutable node of a Pool.
+	shared bool
 }
 
 func newMptNode(data mptNodeData) *MptNode {
@@ -32,25 +39,40 @@ func New() *MptNode {
 // Clear resets the node to an empty state.
 func (m *MptNode) Clear() {
 	m.data = &nullNode{}
-	m.cachedRef = nil
+	m.cachedRef.Store(nil)
 }
 
 // Hash returns the Keccak-256 hash of the node.
 // For null nodes, it returns the EmptyRootHash.
 // For other nodes, it computes the hash from the node reference.
+// The optional walker is called with the RLP encoding of every node of the
+// trie, whether its hash was cached or not.
 func (m *MptNode) Hash(wlkOpt ...func([]byte)) (common.Hash, error) {
+	return m.HashWithin(nil, wlkOpt...)
+}
+
+// HashWithin is Hash for large tries that takes its extra goroutines from the
+// free slots of sem, so that concurrent callers share one limit. A nil sem
+// has GOMAXPROCS slots.
+func (m *MptNode) HashWithin(sem Semaphore, wlkOpt ...func([]byte)) (common.Hash, error) {
 	_, ok := m.data.(*nullNode)
 	if ok {
 		return types.EmptyRootHash, nil
 	}
-	var wlk func([]byte)
-	if len(wlkOpt) != 0 {
-		wlk = wlkOpt[0]
+	if m.unhashed(parallelThreshold) >= parallelThreshold {
+		if err := m.hashParallel(sem); err != nil {
+			return common.Hash{}, err
+		}
 	}
-	ref, err := m.ref(wlk)
+	ref, err := m.ref()
 	if err != nil {
 		return common.Hash{}, err
 	}
+	if len(wlkOpt) != 0 && wlkOpt[0] != nil {
+		if err := m.walk(wlkOpt[0]); err != nil {
+			return common.Hash{}, err
+		}
+	}
 	return ref.hash(), nil
 }
 
@@ -84,19 +106,28 @@ func (m *MptNode) Nibs() []byte {
 // Get retrieves a value from the trie by its key.
 // Returns nil if the key does not exist in the trie.
 func (m *MptNode) Get(key []byte) ([]byte, error) {
-	return m.get(toNibs(key))
+	keyNibs := toNibs(key)
+	if m.store != nil {
+		// digests on the path may be resolved
+		m.ownPath(keyNibs)
+	}
+	return m.get(keyNibs, m.store)
 }
 
 // Delete removes a key-value pair from the trie.
 // Returns true if the key was successfully deleted, false if the key wasn't found.
 func (m *MptNode) Delete(key []byte) (bool, error) {
-	return m.delete(toNibs(key))
+	keyNibs := toNibs(key)
+	m.ownPath(keyNibs)
+	return m.delete(keyNibs, m.store)
 }
 
 // Insert adds or updates a key-value pair in the trie.
 // Returns true if the key was added or modified, false otherwise.
 func (m *MptNode) Insert(key []byte, value []byte) (bool, error) {
-	return m.insert(toNibs(key), value)
+	keyNibs := toNibs(key)
+	m.ownPath(keyNibs)
+	return m.insert(keyNibs, value, m.store)
 }
 
 // InsertRLP encodes the provided value using RLP encoding and inserts it into the trie.
@@ -106,10 +137,10 @@ func (m *MptNode) InsertRLP(key []byte, value any) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	return m.insert(toNibs(key), data)
+	return m.Insert(key, data)
 }
 
-func (m *MptNode) insert(keyNibs []byte, value []byte) (bool, error) {
+func (m *MptNode) insert(keyNibs []byte, value []byte, store NodeStore) (bool, error) {
 	if len(value) == 0 {
 		panic("value must not be empty")
 	}
@@ -126,7 +157,7 @@ func (m *MptNode) insert(keyNibs []byte, value []byte) (bool, error) {
 		idx, tail := keyNibs[0], keyNibs[1:]
 		child := data[idx]
 		if child != nil {
-			ok, err := child.insert(tail, value)
+			ok, err := child.insert(tail, value, store)
 			if err != nil {
 				return false, err
 			}
@@ -173,7 +204,7 @@ func (m *MptNode) insert(keyNibs []byte, value []byte) (bool, error) {
 		selfNibs := prefixNibs(data.prefix)
 		commonLen := lcp(selfNibs, keyNibs)
 		if commonLen == len(selfNibs) {
-			ok, err := data.child.insert(keyNibs[commonLen:], value)
+			ok, err := data.child.insert(keyNibs[commonLen:], value, store)
 			if err != nil {
 				return false, err
 			}
@@ -207,9 +238,12 @@ func (m *MptNode) insert(keyNibs []byte, value []byte) (bool, error) {
 			}
 		}
 	case *digestNode:
-		return false, fmt.Errorf("node not resolved: %#x", data)
+		if err := m.resolveDigest(store); err != nil {
+			return false, err
+		}
+		return m.insert(keyNibs, value, store)
 	}
-	m.cachedRef = nil
+	m.cachedRef.Store(nil)
 	return true, nil
 }
 
@@ -225,7 +259,7 @@ func lcp(a, b []byte) int {
 	return minLen
 }
 
-func (m *MptNode) delete(keyNibs []byte) (bool, error) {
+func (m *MptNode) delete(keyNibs []byte, store NodeStore) (bool, error) {
 	switch data := m.data.(type) {
 	case *nullNode:
 		return false, nil
@@ -238,7 +272,7 @@ func (m *MptNode) delete(keyNibs []byte) (bool, error) {
 		if child == nil {
 			return false, nil
 		}
-		ok, err := child.delete(tail)
+		ok, err := child.delete(tail, store)
 		if err != nil {
 			return false, err
 		}
@@ -265,6 +299,16 @@ func (m *MptNode) delete(keyNibs []byte) (bool, error) {
 		}
 		// if there is only exactly one node left, we need to convert the branch
 		if remaining == 1 {
+			// the remaining child may be merged into the new node, without a
+			// store a digest is kept as the child of an extension
+			if store != nil {
+				if nextChild.shared {
+					nextChild = nextChild.clone()
+				}
+				if err := nextChild.resolveDigest(store); err != nil {
+					return false, err
+				}
+			}
 			switch data := nextChild.data.(type) {
 			case *leafNode:
 				newNibs := slices.Concat([]byte{uint8(nextIdx)}, prefixNibs(data.prefix))
@@ -297,7 +341,7 @@ func (m *MptNode) delete(keyNibs []byte) (bool, error) {
 		selfNibs := prefixNibs(data.prefix)
 		tail := stripPrefix(keyNibs, selfNibs)
 		if tail != nil {
-			ok, err := data.child.delete(tail)
+			ok, err := data.child.delete(tail, store)
 			if err != nil {
 				return false, err
 			}
@@ -327,13 +371,16 @@ func (m *MptNode) delete(keyNibs []byte) (bool, error) {
 		case *branchNode, *digestNode:
 		}
 	case *digestNode:
-		return false, fmt.Errorf("node not resolved: %#x", data)
+		if err := m.resolveDigest(store); err != nil {
+			return false, err
+		}
+		return m.delete(keyNibs, store)
 	}
-	m.cachedRef = nil
+	m.cachedRef.Store(nil)
 	return true, nil
 }
 
-func (m *MptNode) get(keyNibs []byte) ([]byte, error) {
+func (m *MptNode) get(keyNibs []byte, store NodeStore) ([]byte, error) {
 	switch data := m.data.(type) {
 	case *nullNode:
 		return nil, nil
@@ -345,7 +392,7 @@ func (m *MptNode) get(keyNibs []byte) ([]byte, error) {
 		if data[idx] == nil {
 			return nil, nil
 		}
-		return data[idx].get(tail)
+		return data[idx].get(tail, store)
 	case *leafNode:
 		if bytes.Equal(prefixNibs(data.prefix), keyNibs) {
 			return data.value, nil
@@ -353,45 +400,47 @@ func (m *MptNode) get(keyNibs []byte) ([]byte, error) {
 		return nil, nil
 	case *extensionNode:
 		prefix := prefixNibs(data.prefix)
-		return data.child.get(stripPrefix(keyNibs, prefix))
+		return data.child.get(stripPrefix(keyNibs, prefix), store)
 	case *digestNode:
-		return nil, fmt.Errorf("node not resolved: %#x", data)
+		if err := m.resolveDigest(store); err != nil {
+			return nil, err
+		}
+		return m.get(keyNibs, store)
 	}
 	return nil, nil
 }
 
-func (m *MptNode) refEncodeRLP(w rlp.EncoderBuffer, wlk func([]byte)) error {
-	ref, err := m.ref(wlk)
+func (m *MptNode) refEncodeRLP(w rlp.EncoderBuffer) error {
+	ref, err := m.ref()
 	if err != nil {
 		return err
 	}
 	return ref.encodeRLP(w)
 }
 
-func (m *MptNode) ref(wlk func([]byte)) (mptNodeRef, error) {
-	if m.cachedRef == nil {
-		switch data := m.data.(type) {
-		case *nullNode:
-			m.cachedRef = bytesMptNodeRef(rlp.EmptyString)
-		case *digestNode:
-			m.cachedRef = digestMptNodeRef(*data)
-		default:
-			wlkMpt := &walkMptNode{mpt: m, walker: wlk}
-			encoded, err := rlp.EncodeToBytes(wlkMpt)
-			if err != nil {
-				return nil, err
-			}
-			if wlk != nil {
-				wlk(encoded)
-			}
-			if len(encoded) < common.HashLength {
-				m.cachedRef = bytesMptNodeRef(encoded)
-			} else {
-				m.cachedRef = digestMptNodeRef(keccak.Keccak(encoded))
-			}
+func (m *MptNode) ref() (mptNodeRef, error) {
+	if cached := m.cachedRef.Load(); cached != nil {
+		return *cached, nil
+	}
+	var ref mptNodeRef
+	switch data := m.data.(type) {
+	case *nullNode:
+		ref = bytesMptNodeRef(rlp.EmptyString)
+	case *digestNode:
+		ref = digestMptNodeRef(*data)
+	default:
+		encoded, err := rlp.EncodeToBytes(m)
+		if err != nil {
+			return nil, err
+		}
+		if len(encoded) < common.HashLength {
+			ref = bytesMptNodeRef(encoded)
+		} else {
+			ref = digestMptNodeRef(keccak.Keccak(encoded))
 		}
 	}
-	return m.cachedRef, nil
+	m.cachedRef.Store(&ref)
+	return ref, nil
 }
 
 func stripPrefix(nibs []byte, prefix []byte) []byte {
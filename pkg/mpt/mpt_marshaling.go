@@ -2,14 +2,17 @@ package mpt
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/taikoxyz/gaiko/pkg/serde"
 )
 
 var _ json.Unmarshaler = (*MptNode)(nil)
+var _ json.Marshaler = (*MptNode)(nil)
 var _ rlp.Encoder = (*MptNode)(nil)
 
 func (m *MptNode) UnmarshalJSON(data []byte) error {
@@ -39,6 +42,9 @@ func (m *MptNode) UnmarshalJSON(data []byte) error {
 				if err := json.Unmarshal(val, &data); err != nil {
 					return err
 				}
+				if err := checkBranch(&data); err != nil {
+					return err
+				}
 				m.data = &data
 			case "Leaf":
 				// {"data": {"Leaf": [prefix, value]}}
@@ -46,6 +52,9 @@ func (m *MptNode) UnmarshalJSON(data []byte) error {
 				if err := json.Unmarshal(val, &data); err != nil {
 					return err
 				}
+				if err := checkPrefix(data[0], true); err != nil {
+					return err
+				}
 				m.data = &leafNode{
 					prefix: data[0],
 					value:  data[1],
@@ -60,10 +69,16 @@ func (m *MptNode) UnmarshalJSON(data []byte) error {
 				if err := json.Unmarshal(data[0], &prefix); err != nil {
 					return err
 				}
+				if err := checkPrefix(prefix, false); err != nil {
+					return err
+				}
 				var child MptNode
 				if err := json.Unmarshal(data[1], &child); err != nil {
 					return err
 				}
+				if child.data == nil || child.IsEmpty() {
+					return errors.New("extension node without child")
+				}
 				ext := &extensionNode{
 					prefix: prefix,
 					child:  &child,
@@ -81,14 +96,60 @@ func (m *MptNode) UnmarshalJSON(data []byte) error {
 			}
 		}
 	}
+	if m.data == nil {
+		return errors.New("missing MptNodeData")
+	}
 	return nil
 }
 
-func (m *MptNode) EncodeRLP(_w io.Writer) error {
-	return m.encodeRLP(_w, nil)
+// checkPrefix checks the hex-prefix encoded path of a leaf or extension node.
+func checkPrefix(prefix []byte, isLeaf bool) error {
+	if len(prefix) == 0 || prefix[0]>>4 > 3 || (prefix[0]&0x20 != 0) != isLeaf {
+		return fmt.Errorf("invalid node prefix: %#x", prefix)
+	}
+	return nil
 }
 
-func (m *MptNode) encodeRLP(_w io.Writer, wlk func([]byte)) error {
+// checkBranch checks that a branch node has at least two children, otherwise
+// it would be a leaf or an extension.
+func checkBranch(branch *branchNode) error {
+	children := 0
+	for _, child := range branch {
+		if child == nil {
+			continue
+		}
+		if child.IsEmpty() {
+			return errors.New("branch node with a null child")
+		}
+		children++
+	}
+	if children < 2 {
+		return fmt.Errorf("branch node with %d children", children)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the node in the raiko format, see UnmarshalJSON.
+func (m *MptNode) MarshalJSON() ([]byte, error) {
+	var data any
+	switch node := m.data.(type) {
+	case nil, *nullNode:
+		data = "Null"
+	case *branchNode:
+		data = map[string]any{"Branch": node}
+	case *leafNode:
+		data = map[string]any{"Leaf": [2]serde.ByteList{node.prefix, node.value}}
+	case *extensionNode:
+		data = map[string]any{"Extension": [2]any{serde.ByteList(node.prefix), node.child}}
+	case *digestNode:
+		data = map[string]any{"Digest": common.Hash(*node)}
+	default:
+		return nil, fmt.Errorf("unknown MptNodeData type: %T", node)
+	}
+	return json.Marshal(map[string]any{"data": data})
+}
+
+func (m *MptNode) EncodeRLP(_w io.Writer) error {
 	w := rlp.NewEncoderBuffer(_w)
 	switch data := m.data.(type) {
 	case *nullNode:
@@ -99,7 +160,7 @@ func (m *MptNode) encodeRLP(_w io.Writer, wlk func([]byte)) error {
 			if child == nil {
 				w.Write(rlp.EmptyString)
 			} else {
-				if err := child.refEncodeRLP(w, wlk); err != nil {
+				if err := child.refEncodeRLP(w); err != nil {
 					return err
 				}
 			}
@@ -114,7 +175,7 @@ func (m *MptNode) encodeRLP(_w io.Writer, wlk func([]byte)) error {
 	case *extensionNode:
 		_tmp0 := w.List()
 		w.WriteBytes(data.prefix)
-		if err := data.child.refEncodeRLP(w, wlk); err != nil {
+		if err := data.child.refEncodeRLP(w); err != nil {
 			return err
 		}
 		w.ListEnd(_tmp0)
@@ -125,12 +186,3 @@ func (m *MptNode) encodeRLP(_w io.Writer, wlk func([]byte)) error {
 	}
 	return w.Flush()
 }
-
-type walkMptNode struct {
-	mpt    *MptNode
-	walker func([]byte)
-}
-
-func (m *walkMptNode) EncodeRLP(_w io.Writer) error {
-	return m.mpt.encodeRLP(_w, m.walker)
-}
@@ -1,14 +1,24 @@
 package mpt
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"slices"
+	"sync"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/rlp"
+	gethTrie "github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/taikoxyz/gaiko/pkg/keccak"
@@ -32,7 +42,7 @@ func TestTriePointerNoKeccak(t *testing.T) {
 		expected, err := rlp.EncodeToBytes(node)
 		require.NoError(t, err)
 
-		ref, err := node.ref(nil)
+		ref, err := node.ref()
 		require.NoError(t, err)
 		actual := ref.(bytesMptNodeRef)
 		assert.Equal(t, expected, []byte(actual))
@@ -114,7 +124,7 @@ func TestTiny(t *testing.T) {
 	assert.False(t, trie.IsEmpty())
 	expected, err := hex.DecodeString("d816d680c3208180c220018080808080808080808080808080")
 	require.NoError(t, err)
-	ref, err := trie.ref(nil)
+	ref, err := trie.ref()
 	require.NoError(t, err)
 	actual := ref.(bytesMptNodeRef)
 	assert.Equal(t, expected, []byte(actual))
@@ -180,6 +190,118 @@ func TestInsert(t *testing.T) {
 	}
 }
 
+func TestJSON(t *testing.T) {
+	trie := New()
+	for key, val := range map[string]string{
+		"painting": "a value that is long enough not to be inlined",
+		"paper":    "call",
+		"pass":     "boast",
+		"past":     "ship",
+	} {
+		_, err := trie.Insert([]byte(key), []byte(val))
+		require.NoError(t, err)
+	}
+	expected, err := trie.Hash()
+	require.NoError(t, err)
+
+	// replace a subtrie with its digest to cover all node kinds
+	partial := New()
+	require.NoError(t, json.Unmarshal(mustMarshal(t, trie), partial))
+	ext, ok := partial.data.(*extensionNode)
+	require.True(t, ok)
+	child, ok := ext.child.data.(*branchNode)
+	require.True(t, ok)
+	for i, node := range child {
+		if node == nil {
+			continue
+		}
+		ref, err := node.ref()
+		require.NoError(t, err)
+		if digest, ok := ref.(digestMptNodeRef); ok {
+			child[i] = newMptNode((*digestNode)(&digest))
+			break
+		}
+	}
+	require.True(t, slices.ContainsFunc(child[:], func(n *MptNode) bool {
+		return n != nil && n.IsDigest()
+	}))
+
+	for _, node := range []*MptNode{New(), trie, partial} {
+		data := mustMarshal(t, node)
+		dec := New()
+		require.NoError(t, json.Unmarshal(data, dec))
+		want, err := node.Hash()
+		require.NoError(t, err)
+		got, err := dec.Hash()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+		assert.Equal(t, data, mustMarshal(t, dec))
+	}
+	actual, err := partial.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.JSONEq(t, `{"data":"Null"}`, string(mustMarshal(t, New())))
+}
+
+func TestBinary(t *testing.T) {
+	const N = 256
+	trie := New()
+	keys := make([][]byte, N)
+	for i := range N {
+		keys[i] = keccak.Keccak(keyFunc(i)).Bytes()
+		_, err := trie.InsertRLP(keys[i], uint(i))
+		require.NoError(t, err)
+	}
+	var nodes [][]byte
+	root, err := trie.Hash(func(node []byte) {
+		nodes = append(nodes, node)
+	})
+	require.NoError(t, err)
+	digest, err := FromProofs(root)
+	require.NoError(t, err)
+	partial, err := FromProofs(root)
+	require.NoError(t, err)
+	require.NoError(t, partial.Resolve(NewNodeSet(nodes...), keys[0], keys[1]))
+
+	for _, node := range []*MptNode{New(), trie, digest, partial} {
+		data, err := node.MarshalBinary()
+		require.NoError(t, err)
+		dec := New()
+		require.NoError(t, dec.UnmarshalBinary(data))
+		want, err := node.Hash()
+		require.NoError(t, err)
+		got, err := dec.Hash()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+		// digests are kept
+		assert.Equal(t, mustMarshal(t, node), mustMarshal(t, dec))
+		again, err := dec.MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, data, again)
+		assert.Less(t, len(data), len(mustMarshal(t, node)))
+	}
+	require.Error(t, New().UnmarshalBinary([]byte{0xc0, 0x00}))
+
+	// DecodeRLP is the inverse of EncodeRLP for a single node
+	proof, err := trie.Prove(keys[0])
+	require.NoError(t, err)
+	encodedDigest, err := rlp.EncodeToBytes(digest)
+	require.NoError(t, err)
+	for _, encoded := range append(proof, rlp.EmptyString, encodedDigest) {
+		var node MptNode
+		require.NoError(t, rlp.DecodeBytes(encoded, &node))
+		again, err := rlp.EncodeToBytes(&node)
+		require.NoError(t, err)
+		assert.Equal(t, encoded, again)
+	}
+}
+
+func mustMarshal(t testing.TB, node *MptNode) []byte {
+	data, err := json.Marshal(node)
+	require.NoError(t, err)
+	return data
+}
+
 func keyFunc(i int) []byte {
 	switch intSize {
 	case 32:
@@ -231,6 +353,455 @@ func TestMpt(t *testing.T) {
 	}
 }
 
+func TestParallelHash(t *testing.T) {
+	const N = 8192
+	parallel, serial, limited := New(), New(), New()
+	for i := range N {
+		key := keccak.Keccak(keyFunc(i)).Bytes()
+		_, err := parallel.InsertRLP(key, uint(i))
+		require.NoError(t, err)
+		_, err = serial.InsertRLP(key, uint(i))
+		require.NoError(t, err)
+		_, err = limited.InsertRLP(key, uint(i))
+		require.NoError(t, err)
+	}
+	require.GreaterOrEqual(t, parallel.unhashed(parallelThreshold), parallelThreshold)
+
+	collect := func(nodes map[string]int) func([]byte) {
+		return func(node []byte) {
+			nodes[string(node)]++
+		}
+	}
+	parallelNodes, serialNodes := map[string]int{}, map[string]int{}
+	ref, err := serial.ref()
+	require.NoError(t, err)
+	actual, err := parallel.Hash(collect(parallelNodes))
+	require.NoError(t, err)
+	assert.Equal(t, ref.hash(), actual)
+	// the walker sees every node whether its hash is cached or not
+	_, err = serial.Hash(collect(serialNodes))
+	require.NoError(t, err)
+	assert.Equal(t, serialNodes, parallelNodes)
+
+	// the caller hashes alone when the shared semaphore is taken
+	sem := NewSemaphore(1)
+	require.True(t, sem.TryAcquire())
+	actual, err = limited.HashWithin(sem)
+	require.NoError(t, err)
+	assert.Equal(t, ref.hash(), actual)
+	assert.Len(t, sem, 1)
+	sem.Release()
+
+	// cached references are reset along the path of a mutation
+	for i := range N / 2 {
+		key := keccak.Keccak(keyFunc(i)).Bytes()
+		_, err := parallel.Delete(key)
+		require.NoError(t, err)
+		_, err = serial.Delete(key)
+		require.NoError(t, err)
+	}
+	ref, err = serial.ref()
+	require.NoError(t, err)
+	actual, err = parallel.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, ref.hash(), actual)
+}
+
+func TestProof(t *testing.T) {
+	const N = 256
+	trie := New()
+	ref := gethTrie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	for i := range N {
+		key := keccak.Keccak(keyFunc(i)).Bytes()
+		val, err := rlp.EncodeToBytes(uint(i))
+		require.NoError(t, err)
+		_, err = trie.Insert(key, val)
+		require.NoError(t, err)
+		require.NoError(t, ref.Update(key, val))
+	}
+	root, err := trie.Hash()
+	require.NoError(t, err)
+	require.Equal(t, ref.Hash(), root)
+
+	var proofs [][]byte
+	for i := range N + 16 {
+		key := keccak.Keccak(keyFunc(i)).Bytes()
+		proof, err := trie.Prove(key)
+		require.NoError(t, err)
+		var expected trienode.ProofList
+		require.NoError(t, ref.Prove(key, &expected))
+		require.Len(t, proof, len(expected))
+		for j := range proof {
+			assert.Equal(t, []byte(expected[j]), proof[j])
+		}
+
+		val, err := VerifyProof(root, key, proof)
+		require.NoError(t, err)
+		db := memorydb.New()
+		for _, node := range proof {
+			require.NoError(t, db.Put(keccak.Keccak(node).Bytes(), node))
+		}
+		expectedVal, err := gethTrie.VerifyProof(root, key, db)
+		require.NoError(t, err)
+		assert.Equal(t, expectedVal, val)
+		if i < N {
+			require.NotNil(t, val)
+		} else {
+			require.Nil(t, val)
+		}
+
+		if i%16 == 0 {
+			proofs = append(proofs, proof)
+		}
+	}
+
+	_, err = VerifyProof(root, keccak.Keccak(keyFunc(0)).Bytes(), proofs[0][:len(proofs[0])-1])
+	require.Error(t, err)
+
+	// a partial trie built from the proofs has the same root and values
+	partial, err := FromProofs(root, proofs...)
+	require.NoError(t, err)
+	hash, err := partial.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, root, hash)
+	for i := 0; i < N; i += 16 {
+		key := keccak.Keccak(keyFunc(i)).Bytes()
+		expected, err := trie.Get(key)
+		require.NoError(t, err)
+		actual, err := partial.Get(key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+	unresolved := 0
+	for i := range N {
+		if _, err := partial.Get(keccak.Keccak(keyFunc(i)).Bytes()); err != nil {
+			unresolved++
+		}
+	}
+	assert.Positive(t, unresolved)
+
+	empty, err := FromProofs(types.EmptyRootHash)
+	require.NoError(t, err)
+	assert.True(t, empty.IsEmpty())
+}
+
+func TestResolve(t *testing.T) {
+	const N = 256
+	trie := New()
+	for i := range N {
+		_, err := trie.InsertRLP(keccak.Keccak(keyFunc(i)).Bytes(), uint(i))
+		require.NoError(t, err)
+	}
+	var nodes [][]byte
+	root, err := trie.Hash(func(node []byte) {
+		nodes = append(nodes, node)
+	})
+	require.NoError(t, err)
+	store := NewNodeSet(nodes...)
+
+	t.Run("lazy", func(t *testing.T) {
+		partial := newMptNode((*digestNode)(&root))
+		_, err := partial.Get(keccak.Keccak(keyFunc(0)).Bytes())
+		require.Error(t, err)
+
+		partial.SetNodeStore(store)
+		expected := New()
+		for i := range N {
+			key := keccak.Keccak(keyFunc(i)).Bytes()
+			val, err := partial.Get(key)
+			require.NoError(t, err)
+			want, err := trie.Get(key)
+			require.NoError(t, err)
+			assert.Equal(t, want, val)
+			if i%2 == 0 {
+				_, err = partial.Delete(key)
+			} else {
+				_, err = expected.Insert(key, val)
+			}
+			require.NoError(t, err)
+		}
+		_, err = partial.InsertRLP(keccak.Keccak(keyFunc(N)).Bytes(), uint(N))
+		require.NoError(t, err)
+		_, err = expected.InsertRLP(keccak.Keccak(keyFunc(N)).Bytes(), uint(N))
+		require.NoError(t, err)
+
+		want, err := expected.Hash()
+		require.NoError(t, err)
+		got, err := partial.Hash()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("eager", func(t *testing.T) {
+		key := keccak.Keccak(keyFunc(1)).Bytes()
+		partial := newMptNode((*digestNode)(&root))
+		require.NoError(t, partial.Resolve(store, key))
+		val, err := partial.Get(key)
+		require.NoError(t, err)
+		want, err := trie.Get(key)
+		require.NoError(t, err)
+		assert.Equal(t, want, val)
+
+		missing := newMptNode((*digestNode)(&root))
+		require.Error(t, missing.Resolve(NodeSet{}, key))
+
+		require.NoError(t, partial.Resolve(store))
+		for i := range N {
+			_, err := partial.Get(keccak.Keccak(keyFunc(i)).Bytes())
+			require.NoError(t, err)
+		}
+		hash, err := partial.Hash()
+		require.NoError(t, err)
+		assert.Equal(t, root, hash)
+	})
+}
+
+func TestLeaves(t *testing.T) {
+	const N = 256
+	trie := New()
+	keys := make([][]byte, N)
+	for i := range N {
+		keys[i] = keccak.Keccak(keyFunc(i)).Bytes()
+		_, err := trie.InsertRLP(keys[i], uint(i))
+		require.NoError(t, err)
+	}
+	slices.SortFunc(keys, bytes.Compare)
+
+	collect := func(node *MptNode, opts *IterOptions) [][]byte {
+		var res [][]byte
+		for key, val := range node.Leaves(opts) {
+			expected, err := trie.Get(key)
+			require.NoError(t, err)
+			require.Equal(t, expected, val)
+			res = append(res, key)
+		}
+		return res
+	}
+	assert.Equal(t, keys, collect(trie, nil))
+	assert.Equal(t, keys[100:], collect(trie, &IterOptions{Start: keys[100]}))
+	assert.Equal(t, keys[101:], collect(trie, &IterOptions{Start: append(slices.Clone(keys[100]), 0)}))
+
+	prefix := keys[10][:1]
+	var withPrefix [][]byte
+	for _, key := range keys {
+		if bytes.HasPrefix(key, prefix) {
+			withPrefix = append(withPrefix, key)
+		}
+	}
+	assert.Equal(t, withPrefix, collect(trie, &IterOptions{Prefix: prefix}))
+	assert.Equal(t, withPrefix[1:], collect(trie, &IterOptions{Start: withPrefix[1], Prefix: prefix}))
+
+	count := 0
+	for range trie.Leaves(nil) {
+		count++
+		if count == 10 {
+			break
+		}
+	}
+	assert.Equal(t, 10, count)
+
+	// leaves below digests are skipped and reported
+	var nodes [][]byte
+	root, err := trie.Hash(func(node []byte) {
+		nodes = append(nodes, node)
+	})
+	require.NoError(t, err)
+	partial, err := FromProofs(root)
+	require.NoError(t, err)
+	require.NoError(t, partial.Resolve(NewNodeSet(nodes...), keys[0]))
+	var digests [][]byte
+	leaves := collect(partial, &IterOptions{Digest: func(path []byte, _ common.Hash) {
+		digests = append(digests, path)
+	}})
+	assert.Equal(t, keys[:1], leaves[:1])
+	assert.NotEmpty(t, digests)
+	assert.True(t, slices.IsSortedFunc(digests, bytes.Compare))
+
+	partial.SetNodeStore(NewNodeSet(nodes...))
+	assert.Equal(t, keys, collect(partial, nil))
+
+	// iterations only read the trie, they may run concurrently
+	var wg sync.WaitGroup
+	counts := make([]int, 4)
+	for i := range counts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range partial.Leaves(nil) {
+				counts[i]++
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []int{N, N, N, N}, counts)
+	partial.SetNodeStore(nil)
+	digests = nil
+	collect(partial, &IterOptions{Digest: func(path []byte, _ common.Hash) {
+		digests = append(digests, path)
+	}})
+	assert.NotEmpty(t, digests)
+}
+
+func TestDiff(t *testing.T) {
+	const N = 128
+	a, b := New(), New()
+	keys := make([][]byte, N+1)
+	for i := range N + 1 {
+		keys[i] = keccak.Keccak(keyFunc(i)).Bytes()
+	}
+	for i := range N {
+		_, err := a.InsertRLP(keys[i], uint(i))
+		require.NoError(t, err)
+		_, err = b.InsertRLP(keys[i], uint(i))
+		require.NoError(t, err)
+	}
+	diffs, err := Diff(a, b)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	_, err = b.Delete(keys[1])
+	require.NoError(t, err)
+	_, err = b.InsertRLP(keys[2], uint(N+2))
+	require.NoError(t, err)
+	_, err = b.InsertRLP(keys[N], uint(N))
+	require.NoError(t, err)
+
+	diffs, err = Diff(a, b)
+	require.NoError(t, err)
+	expected := map[string]DiffKind{
+		string(keys[1]): DiffRemoved,
+		string(keys[2]): DiffChanged,
+		string(keys[N]): DiffAdded,
+	}
+	require.Len(t, diffs, len(expected))
+	for _, diff := range diffs {
+		assert.Equal(t, expected[string(diff.Key)], diff.Kind, diff.String())
+		valA, err := a.Get(diff.Key)
+		require.NoError(t, err)
+		valB, err := b.Get(diff.Key)
+		require.NoError(t, err)
+		assert.Equal(t, valA, diff.A)
+		assert.Equal(t, valB, diff.B)
+	}
+	assert.True(t, slices.IsSortedFunc(diffs, func(x, y *Difference) int {
+		return bytes.Compare(x.Key, y.Key)
+	}))
+
+	// equal subtries are only reported when they are a digest on one side
+	var nodes [][]byte
+	root, err := a.Hash(func(node []byte) {
+		nodes = append(nodes, node)
+	})
+	require.NoError(t, err)
+	partial, err := FromProofs(root)
+	require.NoError(t, err)
+	require.NoError(t, partial.Resolve(NewNodeSet(nodes...), keys[0]))
+	diffs, err = Diff(partial, a)
+	require.NoError(t, err)
+	require.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.Equal(t, DiffDigest, diff.Kind)
+		assert.Equal(t, diff.HashA, diff.HashB)
+	}
+
+	diffs, err = Diff(partial, b)
+	require.NoError(t, err)
+	assert.True(t, slices.ContainsFunc(diffs, func(diff *Difference) bool {
+		return diff.Kind == DiffUnresolved
+	}))
+
+	partial.SetNodeStore(NewNodeSet(nodes...))
+	expectedDiffs, err := Diff(a, b)
+	require.NoError(t, err)
+	diffs, err = Diff(partial, b)
+	require.NoError(t, err)
+	assert.Equal(t, expectedDiffs, diffs)
+
+	// the digests resolved by Diff stay digests in the trie
+	partial.SetNodeStore(nil)
+	diffs, err = Diff(partial, a)
+	require.NoError(t, err)
+	assert.NotEmpty(t, diffs)
+
+	diffs, err = Diff(New(), a)
+	require.NoError(t, err)
+	assert.Len(t, diffs, N)
+}
+
+func TestPool(t *testing.T) {
+	const N = 512
+	keys := make([][]byte, N+1)
+	for i := range N + 1 {
+		keys[i] = keccak.Keccak(keyFunc(i)).Bytes()
+	}
+	trie := New()
+	for i := range N {
+		_, err := trie.InsertRLP(keys[i], uint(i))
+		require.NoError(t, err)
+	}
+	root, err := trie.Hash()
+	require.NoError(t, err)
+	decode := func() *MptNode {
+		var node MptNode
+		require.NoError(t, json.Unmarshal(mustMarshal(t, trie), &node))
+		return &node
+	}
+
+	pool := NewPool()
+	a, b := decode(), decode()
+	require.NoError(t, pool.Intern(a))
+	size := pool.Len()
+	require.NoError(t, pool.Intern(b))
+	assert.Equal(t, size, pool.Len())
+	for _, node := range []*MptNode{a, b} {
+		actual, err := node.Hash()
+		require.NoError(t, err)
+		assert.Equal(t, root, actual)
+	}
+
+	// mutations copy the shared nodes
+	_, err = b.InsertRLP(keys[N], uint(N))
+	require.NoError(t, err)
+	_, err = b.Delete(keys[0])
+	require.NoError(t, err)
+	actual, err := a.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, root, actual)
+	val, err := a.Get(keys[0])
+	require.NoError(t, err)
+	assert.NotNil(t, val)
+	_, err = trie.InsertRLP(keys[N], uint(N))
+	require.NoError(t, err)
+	_, err = trie.Delete(keys[0])
+	require.NoError(t, err)
+	expected, err := trie.Hash()
+	require.NoError(t, err)
+	actual, err = b.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// equal hashes with different digests are kept apart
+	var nodes [][]byte
+	_, err = a.Hash(func(node []byte) {
+		nodes = append(nodes, node)
+	})
+	require.NoError(t, err)
+	partial, err := FromProofs(root)
+	require.NoError(t, err)
+	require.NoError(t, partial.Resolve(NewNodeSet(nodes...), keys[1]))
+	require.NoError(t, pool.Intern(partial))
+	diffs, err := Diff(partial, a)
+	require.NoError(t, err)
+	assert.NotEmpty(t, diffs)
+	for _, diff := range diffs {
+		assert.Equal(t, DiffDigest, diff.Kind)
+	}
+	require.NoError(t, partial.Resolve(NewNodeSet(nodes...)))
+	diffs, err = Diff(partial, a)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+}
+
 func TestKeccak(t *testing.T) {
 	key := keyFunc(1)
 	expected := keccak.Keccak(key)
@@ -312,6 +883,127 @@ func TestIndexTrie(t *testing.T) {
 	assert.True(t, trie.IsEmpty())
 }
 
+func TestListTrie(t *testing.T) {
+	to := common.HexToAddress("0x1")
+	for _, n := range []int{0, 1, 2, 127, 128, 129, 300} {
+		t.Run(fmt.Sprintf("len %d", n), func(t *testing.T) {
+			txs := make(types.Transactions, n)
+			for i := range txs {
+				txs[i] = types.NewTransaction(uint64(i), to, big.NewInt(int64(i)), 21000, big.NewInt(1), nil)
+			}
+			var keep []int
+			if n > 0 {
+				keep = []int{0, n / 2, n - 1}
+			}
+			trie, err := NewListTrie(txs, keep...)
+			require.NoError(t, err)
+			root, err := trie.Hash()
+			require.NoError(t, err)
+			assert.Equal(t, types.DeriveSha(txs, gethTrie.NewStackTrie(nil)), root)
+
+			for _, i := range keep {
+				proof, err := trie.Prove(IndexKey(i))
+				require.NoError(t, err)
+				val, err := VerifyProof(root, IndexKey(i), proof)
+				require.NoError(t, err)
+				expected, err := txs[i].MarshalBinary()
+				require.NoError(t, err)
+				assert.Equal(t, expected, val)
+			}
+		})
+	}
+
+	trie := NewStackTrie()
+	require.NoError(t, trie.Update([]byte{1}, []byte{1}))
+	require.Error(t, trie.Update([]byte{1}, []byte{2}))
+	require.Error(t, trie.Update([]byte{0}, []byte{2}))
+}
+
+// FuzzTrie applies a sequence of operations to an MptNode and geth's trie,
+// each operation is a kind, a two byte key and a value length followed by the
+// value.
+func FuzzTrie(f *testing.F) {
+	f.Add([]byte{0, 0, 1, 1, 0xaa, 0, 0, 2, 1, 0xbb, 2, 0, 1, 0, 1, 0, 1, 0})
+	f.Add([]byte{0, 0x12, 0x34, 2, 1, 2, 0, 0x12, 0x35, 1, 3, 0, 0x13, 0x34, 1, 4, 1, 0x12, 0x34, 0})
+	f.Add(bytes.Repeat([]byte{0, 0xab, 0xcd, 33}, 12))
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		trie := New()
+		ref := gethTrie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+		for len(ops) >= 4 {
+			kind, key := ops[0]%3, ops[1:3]
+			n := min(int(ops[3]), len(ops)-4)
+			value := ops[4 : 4+n]
+			ops = ops[4+n:]
+			switch {
+			case kind == 0 && len(value) != 0:
+				_, err := trie.Insert(key, value)
+				require.NoError(t, err)
+				require.NoError(t, ref.Update(key, value))
+			case kind == 2:
+				expected, err := ref.Get(key)
+				require.NoError(t, err)
+				actual, err := trie.Get(key)
+				require.NoError(t, err)
+				require.Equal(t, len(expected) != 0, actual != nil)
+				if actual != nil {
+					require.Equal(t, expected, actual)
+				}
+			default:
+				_, err := trie.Delete(key)
+				require.NoError(t, err)
+				require.NoError(t, ref.Delete(key))
+			}
+			actual, err := trie.Hash()
+			require.NoError(t, err)
+			require.Equal(t, ref.Hash(), actual)
+		}
+	})
+}
+
+// FuzzJSON checks that decoding arbitrary nodes fails instead of panicking
+// later, and that the decoded tries round trip.
+func FuzzJSON(f *testing.F) {
+	trie := New()
+	for key, val := range map[string]string{
+		"painting": "a value that is long enough not to be inlined",
+		"paper":    "call",
+		"pass":     "boast",
+	} {
+		_, err := trie.Insert([]byte(key), []byte(val))
+		require.NoError(f, err)
+	}
+	f.Add(mustMarshal(f, trie))
+	f.Add(mustMarshal(f, New()))
+	f.Add([]byte(`{"data":{"Digest":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"}}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var node MptNode
+		if err := json.Unmarshal(data, &node); err != nil {
+			return
+		}
+		hash, err := node.Hash()
+		if err != nil {
+			return
+		}
+		for key := range node.Leaves(nil) {
+			_, _ = node.Get(key)
+			_, _ = node.Prove(key)
+		}
+		var dec MptNode
+		require.NoError(t, json.Unmarshal(mustMarshal(t, &node), &dec))
+		actual, err := dec.Hash()
+		require.NoError(t, err)
+		require.Equal(t, hash, actual)
+		encoded, err := node.MarshalBinary()
+		require.NoError(t, err)
+		require.NoError(t, dec.UnmarshalBinary(encoded))
+		actual, err = dec.Hash()
+		require.NoError(t, err)
+		require.Equal(t, hash, actual)
+		_, _ = node.Delete([]byte("paper"))
+		_, _ = node.Insert([]byte("paper"), []byte("pass"))
+	})
+}
+
 func TestFmt(t *testing.T) {
 	digestNode := (*digestNode)(&types.EmptyRootHash)
 	fmt.Printf("%#x\n", *digestNode)
@@ -0,0 +1,164 @@
+package mpt
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Pool interns trie nodes by hash, so the tries of consecutive blocks which
+// share most of their nodes are kept in memory once. Interned nodes are
+// immutable: Get, Insert, Delete and Resolve copy the shared nodes they change,
+// while Leaves and Diff report shared digests as unresolved.
+type Pool struct {
+	mu sync.Mutex
+	// nodes lists the interned nodes by hash, nodes with equal hashes differ
+	// when one has a digest where the other has the expanded subtrie.
+	nodes map[common.Hash][]*MptNode
+}
+
+func NewPool() *Pool {
+	return &Pool{nodes: map[common.Hash][]*MptNode{}}
+}
+
+// Len returns the number of interned nodes.
+func (p *Pool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for _, nodes := range p.nodes {
+		n += len(nodes)
+	}
+	return n
+}
+
+// Intern replaces the subtries of m by the equal subtries of the pool and adds
+// the others to it. The root m itself is not shared.
+func (p *Pool) Intern(m *MptNode) error {
+	// hash large tries in parallel before taking the lock
+	if _, err := m.Hash(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.internChildren(m)
+}
+
+func (p *Pool) internChildren(node *MptNode) error {
+	var err error
+	switch data := node.data.(type) {
+	case *branchNode:
+		for i, child := range data {
+			if child == nil {
+				continue
+			}
+			if data[i], err = p.intern(child); err != nil {
+				return err
+			}
+		}
+	case *extensionNode:
+		data.child, err = p.intern(data.child)
+	}
+	return err
+}
+
+// intern returns the pooled node equal to node, the children are interned
+// first so that equal subtries are the same pointers.
+func (p *Pool) intern(node *MptNode) (*MptNode, error) {
+	if node.shared {
+		return node, nil
+	}
+	if err := p.internChildren(node); err != nil {
+		return nil, err
+	}
+	ref, err := node.ref()
+	if err != nil {
+		return nil, err
+	}
+	hash := ref.hash()
+	for _, pooled := range p.nodes[hash] {
+		if sameNode(pooled, node) {
+			return pooled, nil
+		}
+	}
+	node.shared = true
+	p.nodes[hash] = append(p.nodes[hash], node)
+	return node, nil
+}
+
+// sameNode reports whether two nodes with the same hash and interned children
+// are equal.
+func sameNode(a, b *MptNode) bool {
+	switch x := a.data.(type) {
+	case *branchNode:
+		y, ok := b.data.(*branchNode)
+		return ok && *x == *y
+	case *extensionNode:
+		y, ok := b.data.(*extensionNode)
+		return ok && x.child == y.child && bytes.Equal(x.prefix, y.prefix)
+	case *leafNode:
+		_, ok := b.data.(*leafNode)
+		return ok
+	case *digestNode:
+		_, ok := b.data.(*digestNode)
+		return ok
+	default:
+		return false
+	}
+}
+
+// clone returns a private shallow copy of a node.
+func (m *MptNode) clone() *MptNode {
+	var data mptNodeData
+	switch x := m.data.(type) {
+	case *branchNode:
+		y := *x
+		data = &y
+	case *extensionNode:
+		y := *x
+		data = &y
+	case *leafNode:
+		y := *x
+		data = &y
+	case *digestNode:
+		y := *x
+		data = &y
+	default:
+		data = x
+	}
+	node := newMptNode(data)
+	node.cachedRef.Store(m.cachedRef.Load())
+	return node
+}
+
+// ownPath replaces the shared nodes on the path of keyNibs by private copies,
+// m must not be shared.
+func (m *MptNode) ownPath(keyNibs []byte) {
+	node := m
+	for {
+		var child **MptNode
+		switch data := node.data.(type) {
+		case *branchNode:
+			if len(keyNibs) == 0 {
+				return
+			}
+			child, keyNibs = &data[keyNibs[0]], keyNibs[1:]
+		case *extensionNode:
+			prefix := prefixNibs(data.prefix)
+			if !bytes.HasPrefix(keyNibs, prefix) {
+				return
+			}
+			child, keyNibs = &data.child, keyNibs[len(prefix):]
+		default:
+			return
+		}
+		if *child == nil {
+			return
+		}
+		if (*child).shared {
+			*child = (*child).clone()
+		}
+		node = *child
+	}
+}
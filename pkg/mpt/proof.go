@@ -0,0 +1,205 @@
+package mpt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Prove returns the RLP encoded nodes on the path of key, starting with the
+// root node, in the format of `eth_getProof`. Nodes embedded in their parent
+// are not listed separately. The proof of a missing key ends with the node
+// where the path diverges.
+func (m *MptNode) Prove(key []byte) ([][]byte, error) {
+	var proof [][]byte
+	keyNibs := toNibs(key)
+	node := m
+	for node != nil {
+		if data, ok := node.data.(*digestNode); ok {
+			return nil, fmt.Errorf("node not resolved: %#x", *data)
+		}
+		if node.IsEmpty() {
+			break
+		}
+		ref, err := node.ref()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := ref.(digestMptNodeRef); ok || len(proof) == 0 {
+			encoded, err := rlp.EncodeToBytes(node)
+			if err != nil {
+				return nil, err
+			}
+			proof = append(proof, encoded)
+		}
+		node, keyNibs = node.next(keyNibs)
+	}
+	return proof, nil
+}
+
+// next returns the child on the path of keyNibs with the remaining nibbles,
+// nil if the path ends at this node.
+func (m *MptNode) next(keyNibs []byte) (*MptNode, []byte) {
+	switch data := m.data.(type) {
+	case *branchNode:
+		if len(keyNibs) == 0 {
+			return nil, nil
+		}
+		return data[keyNibs[0]], keyNibs[1:]
+	case *extensionNode:
+		prefix := prefixNibs(data.prefix)
+		if !bytes.HasPrefix(keyNibs, prefix) {
+			return nil, nil
+		}
+		return data.child, keyNibs[len(prefix):]
+	default:
+		return nil, nil
+	}
+}
+
+// VerifyProof checks a proof created by Prove or `eth_getProof` against the
+// root hash. It returns the value of key, nil if the proof shows the key is
+// absent, and an error if the proof is incomplete or malformed.
+func VerifyProof(root common.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := NewNodeSet(proof...)
+	node := New()
+	if root != types.EmptyRootHash {
+		node = newMptNode((*digestNode)(&root))
+	}
+	keyNibs := toNibs(key)
+	for node != nil {
+		switch data := node.data.(type) {
+		case *digestNode:
+			encoded, ok := nodes[common.Hash(*data)]
+			if !ok {
+				return nil, fmt.Errorf("missing proof node: %#x", *data)
+			}
+			resolved, err := decodeNode(encoded, false)
+			if err != nil {
+				return nil, err
+			}
+			node = resolved
+			continue
+		case *leafNode:
+			if bytes.Equal(prefixNibs(data.prefix), keyNibs) {
+				return data.value, nil
+			}
+			return nil, nil
+		}
+		node, keyNibs = node.next(keyNibs)
+	}
+	return nil, nil
+}
+
+// FromProofs builds a partial trie with the given root from a set of proofs,
+// e.g. the account or storage proofs of `eth_getProof`. Subtries that are not
+// covered by any proof are kept as digest nodes.
+func FromProofs(root common.Hash, proofs ...[][]byte) (*MptNode, error) {
+	if root == types.EmptyRootHash {
+		return New(), nil
+	}
+	trie := newMptNode((*digestNode)(&root))
+	if err := trie.Resolve(NewNodeSet(slices.Concat(proofs...)...)); err != nil {
+		return nil, err
+	}
+	return trie, nil
+}
+
+// decodeNode decodes a RLP encoded node, children referenced by hash are
+// returned as digest nodes. With inline children of any size are accepted, as
+// written by MarshalBinary.
+func decodeNode(buf []byte, inline bool) (*MptNode, error) {
+	kind, content, rest, err := rlp.Split(buf)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after node")
+	}
+	if kind != rlp.List {
+		if len(content) == 0 {
+			return New(), nil
+		}
+		return nil, fmt.Errorf("invalid node: %#x", buf)
+	}
+	count, err := rlp.CountValues(content)
+	if err != nil {
+		return nil, err
+	}
+	switch count {
+	case 2:
+		prefix, rest, err := rlp.SplitString(content)
+		if err != nil {
+			return nil, err
+		}
+		if len(prefix) == 0 || prefix[0]>>4 > 3 {
+			return nil, fmt.Errorf("invalid node prefix: %#x", prefix)
+		}
+		prefix = common.CopyBytes(prefix)
+		if prefix[0]&0x20 != 0 {
+			value, _, err := rlp.SplitString(rest)
+			if err != nil {
+				return nil, err
+			}
+			return newMptNode(&leafNode{prefix: prefix, value: common.CopyBytes(value)}), nil
+		}
+		child, _, err := decodeRef(rest, inline)
+		if err != nil {
+			return nil, err
+		}
+		if child == nil {
+			return nil, errors.New("extension node without child")
+		}
+		return newMptNode(&extensionNode{prefix: prefix, child: child}), nil
+	case 17:
+		branch := &branchNode{}
+		for i := range branch {
+			if branch[i], content, err = decodeRef(content, inline); err != nil {
+				return nil, err
+			}
+		}
+		value, _, err := rlp.SplitString(content)
+		if err != nil {
+			return nil, err
+		}
+		if len(value) != 0 {
+			return nil, errors.New("branch node with value")
+		}
+		if err := checkBranch(branch); err != nil {
+			return nil, err
+		}
+		return newMptNode(branch), nil
+	default:
+		return nil, fmt.Errorf("invalid number of list elements: %d", count)
+	}
+}
+
+// decodeRef decodes a child reference, it returns nil for an empty reference.
+func decodeRef(buf []byte, inline bool) (*MptNode, []byte, error) {
+	kind, val, rest, err := rlp.Split(buf)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case kind == rlp.List:
+		// embedded node, its encoding is shorter than a hash
+		size := len(buf) - len(rest)
+		if !inline && size >= common.HashLength {
+			return nil, nil, fmt.Errorf("oversized embedded node (size %d)", size)
+		}
+		child, err := decodeNode(buf[:size], inline)
+		return child, rest, err
+	case kind == rlp.String && len(val) == 0:
+		return nil, rest, nil
+	case kind == rlp.String && len(val) == common.HashLength:
+		hash := common.BytesToHash(val)
+		return newMptNode((*digestNode)(&hash)), rest, nil
+	default:
+		return nil, nil, fmt.Errorf("invalid node reference: %#x", val)
+	}
+}
@@ -0,0 +1,149 @@
+package mpt
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// StackTrie builds a trie from keys inserted in increasing order. The subtries
+// left of the last key are complete, so they are hashed and only kept as
+// digests, except for the paths of the keys kept for proofs.
+type StackTrie struct {
+	root *MptNode
+	last []byte
+	keep [][]byte
+}
+
+// NewStackTrie creates an empty StackTrie, the nodes on the paths of keep stay
+// expanded so that Prove works for them.
+func NewStackTrie(keep ...[]byte) *StackTrie {
+	s := &StackTrie{root: New()}
+	for _, key := range keep {
+		s.keep = append(s.keep, toNibs(key))
+	}
+	return s
+}
+
+// Update inserts a key, keys must be inserted in increasing order.
+func (s *StackTrie) Update(key, value []byte) error {
+	if s.last != nil && bytes.Compare(key, s.last) <= 0 {
+		return errors.New("keys not inserted in increasing order")
+	}
+	keyNibs := toNibs(key)
+	if err := s.collapse(s.root, keyNibs, nil); err != nil {
+		return err
+	}
+	if _, err := s.root.insert(keyNibs, value, nil); err != nil {
+		return err
+	}
+	s.last = key
+	return nil
+}
+
+// collapse replaces the subtries left of the path of keyNibs by digests.
+func (s *StackTrie) collapse(node *MptNode, keyNibs, path []byte) error {
+	switch data := node.data.(type) {
+	case *branchNode:
+		if len(keyNibs) == 0 {
+			return nil
+		}
+		for i := range keyNibs[0] {
+			if data[i] == nil {
+				continue
+			}
+			digest, err := s.digest(data[i], slices.Concat(path, []byte{i}))
+			if err != nil {
+				return err
+			}
+			data[i] = digest
+		}
+		if child := data[keyNibs[0]]; child != nil {
+			return s.collapse(child, keyNibs[1:], slices.Concat(path, keyNibs[:1]))
+		}
+	case *extensionNode:
+		prefix := prefixNibs(data.prefix)
+		if bytes.HasPrefix(keyNibs, prefix) {
+			return s.collapse(data.child, keyNibs[len(prefix):], slices.Concat(path, prefix))
+		}
+		// the new key splits the extension, the child is complete
+		digest, err := s.digest(data.child, slices.Concat(path, prefix))
+		if err != nil {
+			return err
+		}
+		data.child = digest
+	}
+	return nil
+}
+
+// digest returns the digest of the complete subtrie at path, embedded nodes
+// and subtries with kept keys are returned as they are.
+func (s *StackTrie) digest(node *MptNode, path []byte) (*MptNode, error) {
+	if node.IsDigest() {
+		return node, nil
+	}
+	for _, key := range s.keep {
+		if bytes.HasPrefix(key, path) {
+			return node, nil
+		}
+	}
+	ref, err := node.ref()
+	if err != nil {
+		return nil, err
+	}
+	hash, ok := ref.(digestMptNodeRef)
+	if !ok {
+		return node, nil
+	}
+	digest := newMptNode((*digestNode)(&hash))
+	digest.cachedRef.Store(&ref)
+	return digest, nil
+}
+
+// Hash returns the root hash of the keys inserted so far.
+func (s *StackTrie) Hash() (common.Hash, error) {
+	return s.root.Hash()
+}
+
+// Prove returns the proof of a key passed to NewStackTrie, see MptNode.Prove.
+func (s *StackTrie) Prove(key []byte) ([][]byte, error) {
+	return s.root.Prove(key)
+}
+
+// IndexKey returns the key of the i-th item of a list trie.
+func IndexKey(i int) []byte {
+	return rlp.AppendUint64(nil, uint64(i))
+}
+
+// NewListTrie builds the trie of an index-keyed list like the transactions,
+// receipts or withdrawals of a block, its root is the one of types.DeriveSha.
+// The items at the indices of keep can be proven.
+func NewListTrie(list types.DerivableList, keep ...int) (*StackTrie, error) {
+	keys := make([][]byte, len(keep))
+	for i, idx := range keep {
+		keys[i] = IndexKey(idx)
+	}
+	s := NewStackTrie(keys...)
+	var buf bytes.Buffer
+	// the RLP encoded indices sort as 1..127, 0, 128..
+	n := list.Len()
+	for i := range n {
+		idx := i + 1
+		switch {
+		case i == min(n, 128)-1:
+			idx = 0
+		case i >= 127:
+			idx = i
+		}
+		buf.Reset()
+		list.EncodeIndex(idx, &buf)
+		if err := s.Update(IndexKey(idx), bytes.Clone(buf.Bytes())); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
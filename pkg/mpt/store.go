@@ -0,0 +1,151 @@
+package mpt
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+)
+
+// NodeStore looks up RLP encoded trie nodes by their hash.
+type NodeStore interface {
+	Node(hash common.Hash) ([]byte, bool)
+}
+
+// NodeSet is a flat set of trie nodes, like the state of geth's
+// `stateless.Witness`.
+type NodeSet map[common.Hash][]byte
+
+var _ NodeStore = NodeSet(nil)
+
+// NewNodeSet creates a NodeSet from RLP encoded nodes.
+func NewNodeSet(nodes ...[]byte) NodeSet {
+	set := make(NodeSet, len(nodes))
+	for _, node := range nodes {
+		set[keccak.Keccak(node)] = node
+	}
+	return set
+}
+
+func (s NodeSet) Node(hash common.Hash) ([]byte, bool) {
+	node, ok := s[hash]
+	return node, ok
+}
+
+// SetNodeStore attaches store to the trie, digests reached by Get, Insert and
+// Delete are resolved from it on demand.
+func (m *MptNode) SetNodeStore(store NodeStore) {
+	m.store = store
+}
+
+// Resolve replaces digest nodes with the nodes of store. With keys only the
+// digests on the paths of keys are resolved and a missing node is an error,
+// otherwise every digest found in store is resolved and the rest are kept.
+func (m *MptNode) Resolve(store NodeStore, keys ...[]byte) error {
+	if len(keys) == 0 {
+		_, err := m.resolveAll(store)
+		return err
+	}
+	for _, key := range keys {
+		node, keyNibs := m, toNibs(key)
+		node.ownPath(keyNibs)
+		for node != nil {
+			if err := node.resolveDigest(store); err != nil {
+				return err
+			}
+			node, keyNibs = node.next(keyNibs)
+		}
+	}
+	return nil
+}
+
+// resolveAll returns m with the digests found in store resolved, a shared
+// node is copied if anything below it changes.
+func (m *MptNode) resolveAll(store NodeStore) (*MptNode, error) {
+	switch data := m.data.(type) {
+	case *digestNode:
+		if _, ok := store.Node(common.Hash(*data)); !ok {
+			return m, nil
+		}
+		node := m
+		if node.shared {
+			node = node.clone()
+		}
+		if err := node.resolveDigest(store); err != nil {
+			return nil, err
+		}
+		return node.resolveAll(store)
+	case *branchNode:
+		children := *data
+		for i, child := range data {
+			if child == nil {
+				continue
+			}
+			resolved, err := child.resolveAll(store)
+			if err != nil {
+				return nil, err
+			}
+			children[i] = resolved
+		}
+		if children == *data {
+			return m, nil
+		}
+		node := m
+		if node.shared {
+			node = node.clone()
+		}
+		*node.data.(*branchNode) = children
+		return node, nil
+	case *extensionNode:
+		child, err := data.child.resolveAll(store)
+		if err != nil {
+			return nil, err
+		}
+		if child == data.child {
+			return m, nil
+		}
+		node := m
+		if node.shared {
+			node = node.clone()
+		}
+		node.data.(*extensionNode).child = child
+		return node, nil
+	}
+	return m, nil
+}
+
+// resolveDigest replaces a digest node with the decoded node from store, the
+// cached reference stays valid since the hash is checked. A shared digest must
+// be copied first.
+func (m *MptNode) resolveDigest(store NodeStore) error {
+	if data, ok := m.data.(*digestNode); ok && m.shared && store != nil {
+		return fmt.Errorf("shared node not resolved: %#x", common.Hash(*data))
+	}
+	node, err := m.resolved(store)
+	if err != nil {
+		return err
+	}
+	m.data = node.data
+	return nil
+}
+
+// resolved returns the node decoded from store that a digest stands for, or
+// m if it isn't a digest. Unlike resolveDigest, m is left untouched.
+func (m *MptNode) resolved(store NodeStore) (*MptNode, error) {
+	data, ok := m.data.(*digestNode)
+	if !ok {
+		return m, nil
+	}
+	hash := common.Hash(*data)
+	if store == nil {
+		return nil, fmt.Errorf("node not resolved: %#x", hash)
+	}
+	encoded, ok := store.Node(hash)
+	if !ok {
+		return nil, fmt.Errorf("missing trie node: %#x", hash)
+	}
+	if keccak.Keccak(encoded) != hash {
+		return nil, fmt.Errorf("trie node hash mismatch: %#x", hash)
+	}
+	return decodeNode(encoded, false)
+}
@@ -0,0 +1,32 @@
+// Package serde holds the JSON encodings shared with raiko's serde output.
+package serde
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// ByteList marshals as a JSON array of numbers like raiko's `Vec<u8>`, it
+// also decodes from a base64 string.
+type ByteList []byte
+
+func (b ByteList) MarshalJSON() ([]byte, error) {
+	out := make([]byte, 0, 2+len(b)*4)
+	out = append(out, '[')
+	for i, v := range b {
+		if i > 0 {
+			out = append(out, ',')
+		}
+		out = strconv.AppendUint(out, uint64(v), 10)
+	}
+	return append(out, ']'), nil
+}
+
+func (b *ByteList) UnmarshalJSON(input []byte) error {
+	var data []byte
+	if err := json.Unmarshal(input, &data); err != nil {
+		return err
+	}
+	*b = data
+	return nil
+}
@@ -5,7 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"runtime"
+	"runtime/metrics"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
@@ -53,3 +56,73 @@ func TestBatch(t *testing.T) {
 		})
 	}
 }
+
+// BenchmarkBatchDecode compares the live heap of a decoded batch, whose tries
+// are interned, with decoding each input on its own.
+func BenchmarkBatchDecode(b *testing.B) {
+	inputs, err := fixtures.GetBatchInputs()
+	require.NoError(b, err)
+
+	for id, input := range inputs {
+		if input.Input == nil {
+			continue
+		}
+		b.Run(fmt.Sprintf("task: %d/stream", id), func(b *testing.B) {
+			benchmarkDecode(b, func() (any, error) {
+				var batch witness.BatchGuestInput
+				return &batch, batch.DecodeStream(bytes.NewReader(input.Input))
+			})
+		})
+		b.Run(fmt.Sprintf("task: %d/separate", id), func(b *testing.B) {
+			benchmarkDecode(b, func() (any, error) {
+				var batch struct {
+					Inputs []*witness.GuestInput `json:"inputs"`
+				}
+				return &batch, json.Unmarshal(input.Input, &batch)
+			})
+		})
+	}
+}
+
+// benchmarkDecode reports the heap still used by the decoded input and the
+// peak heap seen while decoding it.
+func benchmarkDecode(b *testing.B, decode func() (any, error)) {
+	b.ReportAllocs()
+	var live, peak uint64
+	for b.Loop() {
+		runtime.GC()
+		before := heapObjects()
+		done := make(chan uint64)
+		go func() {
+			top := heapObjects()
+			ticker := time.NewTicker(time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					done <- top
+					return
+				case <-ticker.C:
+					top = max(top, heapObjects())
+				}
+			}
+		}()
+		input, err := decode()
+		require.NoError(b, err)
+		done <- 0
+		top := <-done
+		runtime.GC()
+		after := heapObjects()
+		runtime.KeepAlive(input)
+		live = max(live, after-min(after, before))
+		peak = max(peak, top-min(top, before))
+	}
+	b.ReportMetric(float64(live)/(1<<20), "live-MiB")
+	b.ReportMetric(float64(peak)/(1<<20), "peak-MiB")
+}
+
+func heapObjects() uint64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
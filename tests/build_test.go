@@ -0,0 +1,356 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/gaiko/internal/builder"
+	"github.com/taikoxyz/gaiko/internal/flags"
+	"github.com/taikoxyz/gaiko/internal/prover"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/gaiko/tests/fixtures"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
+)
+
+// TestBuildBatch rebuilds a batch witness from endpoints that answer with the
+// content of the fixture, the rebuilt witness must prove the same batch.
+func TestBuildBatch(t *testing.T) {
+	inputs, err := fixtures.GetBatchInputs()
+	require.NoError(t, err)
+	pair := inputs[10619]
+	var fixture witness.BatchGuestInput
+	require.NoError(t, json.Unmarshal(pair.Input, &fixture))
+	var expected BatchGuestOutput
+	require.NoError(t, json.Unmarshal(pair.Output, &expected))
+
+	l2 := httptest.NewServer(rpcHandler(l2Methods(&fixture)))
+	defer l2.Close()
+	mux := http.NewServeMux()
+	proposed, ok := fixture.Taiko.BatchProposed.(*witness.PacayaBlockProposed)
+	require.True(t, ok)
+	inclusion := proposed.Info.AnchorBlockId + 1
+	mux.Handle("/", rpcHandler(l1Methods(t, &fixture, inclusion)))
+	mux.HandleFunc("/eth/v1/beacon/blob_sidecars/", beaconHandler(&fixture))
+	l1 := httptest.NewServer(mux)
+	defer l1.Close()
+
+	l1ChainSpec, err := witness.LookupChainSpec(witness.EthereumNetwork)
+	require.NoError(t, err)
+	for _, flat := range []bool{false, true} {
+		t.Run(fmt.Sprintf("flat: %t", flat), func(t *testing.T) {
+			b, err := builder.New(context.Background(), &builder.Config{
+				ChainSpec:     fixture.Taiko.ChainSpec,
+				L1ChainSpec:   l1ChainSpec,
+				RPC:           l2.URL,
+				L1RPC:         l1.URL,
+				BeaconRPC:     l1.URL,
+				ProverData:    *fixture.Taiko.ProverData,
+				BlobProofType: fixture.Taiko.BlobProofType,
+				Flat:          flat,
+			})
+			require.NoError(t, err)
+			defer b.Close()
+			// the inclusion block is looked up from the batch without one
+			_, err = b.BuildBatch(context.Background(), fixture.Taiko.BatchID+1, 0)
+			require.ErrorContains(t, err, "BatchNotFound")
+			_, err = b.BuildBatch(context.Background(), fixture.Taiko.BatchID, inclusion+1)
+			require.ErrorContains(t, err, "isn't proposed")
+			built, err := b.BuildBatch(context.Background(), fixture.Taiko.BatchID, 0)
+			require.NoError(t, err)
+			require.Len(t, built.Inputs, len(fixture.Inputs))
+			// a slot is only attributed to the storage that holds it
+			for _, g := range built.Inputs {
+				for addr, entry := range g.ParentStorage {
+					for _, slot := range entry.Slots {
+						value, err := entry.Trie.Get(keccak.Keccak(common.BigToHash(slot).Bytes()).Bytes())
+						require.NoError(t, err)
+						assert.NotNil(t, value, "slot %#x of %#x", slot, addr)
+					}
+				}
+			}
+			data, err := json.Marshal(built)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			args := &flags.Arguments{
+				SGXType:       "debug",
+				ProofType:     witness.NativeProofType,
+				WitnessReader: bytes.NewReader(data),
+				ProofWriter:   &out,
+			}
+			require.NoError(t, prover.NewSGXProver(args).BatchOneshot(context.Background(), args))
+			var output prover.ProofResponse
+			require.NoError(t, json.NewDecoder(&out).Decode(&output))
+			assert.Equal(t, expected.Hash, output.Input)
+		})
+	}
+}
+
+type rpcMethod func(params []json.RawMessage) (any, error)
+
+// rpcHandler serves JSON-RPC calls, one at a time since the fixture tries
+// cache their hashes.
+func rpcHandler(methods map[string]rpcMethod) http.HandlerFunc {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		method, ok := methods[req.Method]
+		if !ok {
+			resp["error"] = map[string]any{"code": -32601, "message": "method not found: " + req.Method}
+		} else {
+			mu.Lock()
+			result, err := method(req.Params)
+			mu.Unlock()
+			if err != nil {
+				resp["error"] = map[string]any{"code": -32000, "message": err.Error()}
+			} else {
+				resp["result"] = result
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func l2Methods(fixture *witness.BatchGuestInput) map[string]rpcMethod {
+	blocks := map[uint64]*witness.GuestInput{}
+	parents := map[uint64]*witness.GuestInput{}
+	for _, g := range fixture.Inputs {
+		blocks[g.Block.NumberU64()] = g
+		parents[g.ParentHeader.Number.Uint64()] = g
+	}
+	input := func(m map[uint64]*witness.GuestInput, param json.RawMessage) (*witness.GuestInput, error) {
+		var n hexutil.Uint64
+		if err := json.Unmarshal(param, &n); err != nil {
+			return nil, err
+		}
+		g, ok := m[uint64(n)]
+		if !ok {
+			return nil, fmt.Errorf("unknown block %d", n)
+		}
+		return g, nil
+	}
+	return map[string]rpcMethod{
+		"eth_getBlockByNumber": func(params []json.RawMessage) (any, error) {
+			g, err := input(blocks, params[0])
+			if err != nil {
+				return nil, err
+			}
+			return blockJSON(g.Block)
+		},
+		"debug_executionWitness": func(params []json.RawMessage) (any, error) {
+			g, err := input(blocks, params[0])
+			if err != nil {
+				return nil, err
+			}
+			wit, err := g.NewWitness()
+			if err != nil {
+				return nil, err
+			}
+			ew := &witness.ExecutionWitness{Headers: wit.Headers}
+			for code := range wit.Codes {
+				ew.Codes = append(ew.Codes, []byte(code))
+			}
+			for node := range wit.State {
+				ew.State = append(ew.State, []byte(node))
+			}
+			for addr, entry := range g.ParentStorage {
+				ew.Keys = append(ew.Keys, addr.Bytes())
+				for _, slot := range entry.Slots {
+					ew.Keys = append(ew.Keys, common.BigToHash(slot).Bytes())
+				}
+			}
+			return ew, nil
+		},
+		"eth_getProof": func(params []json.RawMessage) (any, error) {
+			var (
+				addr  common.Address
+				slots []common.Hash
+			)
+			if err := json.Unmarshal(params[0], &addr); err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(params[1], &slots); err != nil {
+				return nil, err
+			}
+			g, err := input(parents, params[2])
+			if err != nil {
+				return nil, err
+			}
+			accountProof, err := g.ParentStateTrie.Prove(keccak.Keccak(addr.Bytes()).Bytes())
+			if err != nil {
+				return nil, err
+			}
+			storageHash := types.EmptyRootHash
+			if data, err := g.ParentStateTrie.Get(keccak.Keccak(addr.Bytes()).Bytes()); err != nil {
+				return nil, err
+			} else if data != nil {
+				var acc types.StateAccount
+				if err := rlp.DecodeBytes(data, &acc); err != nil {
+					return nil, err
+				}
+				storageHash = acc.Root
+			}
+			type storageProof struct {
+				Key   common.Hash     `json:"key"`
+				Proof []hexutil.Bytes `json:"proof"`
+			}
+			storageProofs := []storageProof{}
+			for _, slot := range slots {
+				proof, err := g.ParentStorage[addr].Trie.Prove(keccak.Keccak(slot.Bytes()).Bytes())
+				if err != nil {
+					return nil, err
+				}
+				storageProofs = append(storageProofs, storageProof{Key: slot, Proof: toHexBytes(proof)})
+			}
+			return map[string]any{
+				"address":      addr,
+				"accountProof": toHexBytes(accountProof),
+				"storageHash":  storageHash,
+				"storageProof": storageProofs,
+			}, nil
+		},
+	}
+}
+
+// l1Methods answers every header with the L1 header of the fixture, the logs
+// of the inclusion block with its BatchProposed event and getBatch of the inbox
+// with the batch of the fixture.
+func l1Methods(t *testing.T, fixture *witness.BatchGuestInput, inclusion uint64) map[string]rpcMethod {
+	proposed, ok := fixture.Taiko.BatchProposed.(*witness.PacayaBlockProposed)
+	require.True(t, ok)
+	data, err := proposed.ABIEncode()
+	require.NoError(t, err)
+	log := &types.Log{
+		Address:     *fixture.Taiko.ChainSpec.L1Contract,
+		Topics:      []common.Hash{encoding.TaikoInboxABI.Events["BatchProposed"].ID},
+		Data:        data,
+		BlockNumber: inclusion,
+	}
+	// the batch is a tuple of static fields, a word each
+	getBatch := encoding.TaikoInboxABI.Methods["getBatch"]
+	fields := getBatch.Outputs[0].Type.TupleRawNames
+	batch := make([]byte, 32*len(fields))
+	for i, name := range fields {
+		var value uint64
+		switch name {
+		case "batchId":
+			value = fixture.Taiko.BatchID
+		case "lastBlockId":
+			value = proposed.Info.LastBlockId
+		case "anchorBlockId":
+			value = proposed.Info.AnchorBlockId
+		}
+		new(big.Int).SetUint64(value).FillBytes(batch[32*i : 32*(i+1)])
+	}
+	return map[string]rpcMethod{
+		"eth_getBlockByNumber": func([]json.RawMessage) (any, error) {
+			return fixture.Taiko.L1Header, nil
+		},
+		"eth_getLogs": func(params []json.RawMessage) (any, error) {
+			var filter struct {
+				FromBlock hexutil.Uint64 `json:"fromBlock"`
+				ToBlock   hexutil.Uint64 `json:"toBlock"`
+			}
+			if err := json.Unmarshal(params[0], &filter); err != nil {
+				return nil, err
+			}
+			if uint64(filter.FromBlock) > inclusion || uint64(filter.ToBlock) < inclusion {
+				return []*types.Log{}, nil
+			}
+			return []*types.Log{log}, nil
+		},
+		"eth_call": func(params []json.RawMessage) (any, error) {
+			var call struct {
+				Input hexutil.Bytes `json:"input"`
+				Data  hexutil.Bytes `json:"data"`
+			}
+			if err := json.Unmarshal(params[0], &call); err != nil {
+				return nil, err
+			}
+			input := call.Input
+			if len(input) == 0 {
+				input = call.Data
+			}
+			if len(input) < 4 || !bytes.Equal(input[:4], getBatch.ID) {
+				return nil, errors.New("unexpected call")
+			}
+			args, err := getBatch.Inputs.Unpack(input[4:])
+			if err != nil {
+				return nil, err
+			}
+			if args[0].(uint64) != fixture.Taiko.BatchID {
+				return nil, errors.New("execution reverted: BatchNotFound")
+			}
+			return hexutil.Bytes(batch), nil
+		},
+	}
+}
+
+// beaconHandler serves the blobs of the fixture as the sidecars of any slot.
+func beaconHandler(fixture *witness.BatchGuestInput) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		taiko := fixture.Taiko
+		sidecars := []map[string]any{}
+		for i, blob := range taiko.TxDataFromBlob {
+			sidecars = append(sidecars, map[string]any{
+				"index":          fmt.Sprint(i),
+				"blob":           hexutil.Bytes(blob[:]),
+				"kzg_commitment": hexutil.Bytes((*taiko.BlobCommitments)[i][:]),
+				"kzg_proof":      hexutil.Bytes((*taiko.BlobProofs)[i][:]),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": sidecars})
+	}
+}
+
+// blockJSON encodes a block like `eth_getBlockByNumber` with full transactions.
+func blockJSON(block *types.Block) (map[string]any, error) {
+	data, err := json.Marshal(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	fields["transactions"] = block.Transactions()
+	fields["uncles"] = []common.Hash{}
+	if withdrawals := block.Withdrawals(); withdrawals != nil {
+		fields["withdrawals"] = withdrawals
+	}
+	return fields, nil
+}
+
+func toHexBytes(nodes [][]byte) []hexutil.Bytes {
+	res := make([]hexutil.Bytes, len(nodes))
+	for i, node := range nodes {
+		res[i] = node
+	}
+	return res
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/gaiko/internal/transition"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/gaiko/pkg/mpt"
+	"github.com/taikoxyz/gaiko/tests/fixtures"
+)
+
+func TestCheckSingle(t *testing.T) {
+	inputs, err := fixtures.GetSingleInputs()
+	require.NoError(t, err)
+
+	for id, input := range inputs {
+		if input.Input == nil {
+			continue
+		}
+		t.Run(fmt.Sprintf("task: %d", id), func(t *testing.T) {
+			decode := func() *witness.GuestInput {
+				var g witness.GuestInput
+				require.NoError(t, json.Unmarshal(input.Input, &g))
+				return &g
+			}
+			check := func(g *witness.GuestInput) *transition.Report {
+				reports, err := transition.Check(g)
+				require.NoError(t, err)
+				require.Len(t, reports, 1)
+				return reports[0]
+			}
+
+			g := decode()
+			report := check(g)
+			assert.NoError(t, report.Err)
+			assert.True(t, report.Complete())
+
+			t.Run("missing code", func(t *testing.T) {
+				// the anchor transaction runs the code of the anchor contract
+				g := decode()
+				require.Less(t, len(report.UnusedContracts), len(g.Contracts))
+				g.Contracts = nil
+				report := check(g)
+				assert.NotEmpty(t, report.MissingCodes)
+				assert.False(t, report.Complete())
+			})
+
+			t.Run("missing account", func(t *testing.T) {
+				// keep only the root of the state trie
+				g := decode()
+				ew := flatten(t, g, func(node []byte) bool {
+					return keccak.Keccak(node) == g.ParentHeader.Root
+				}, nil)
+				g.ExecutionWitness = ew
+				report := check(g)
+				assert.NotEmpty(t, report.MissingAccounts)
+				assert.False(t, report.Complete())
+			})
+
+			t.Run("missing slot", func(t *testing.T) {
+				g := decode()
+				var addr common.Address
+				var root common.Hash
+				for a, storage := range g.ParentStorage {
+					hash, err := storage.Trie.Hash()
+					require.NoError(t, err)
+					if len(storage.Slots) != 0 && hash != types.EmptyRootHash &&
+						!slices.Contains(report.UnusedNodes, hash) {
+						addr, root = a, hash
+						break
+					}
+				}
+				require.NotEqual(t, common.Hash{}, root, "no storage read in the block")
+				ew := flatten(t, g, nil, func(node []byte) bool {
+					return keccak.Keccak(node) != root
+				})
+				g.ExecutionWitness = ew
+				report := check(g)
+				assert.True(t, slices.ContainsFunc(report.MissingSlots, func(s transition.Slot) bool {
+					return s.Address == addr
+				}))
+				assert.False(t, report.Complete())
+			})
+
+			t.Run("missing header", func(t *testing.T) {
+				// the anchor transaction hashes the 255 ancestors of the block
+				g := decode()
+				num := g.ParentHeader.Number.Uint64() - 1
+				i := slices.IndexFunc(g.AncestorHeaders, func(h *types.Header) bool {
+					return h.Number.Uint64() == num
+				})
+				require.NotEqual(t, -1, i)
+				g.AncestorHeaders = slices.Delete(g.AncestorHeaders, i, i+1)
+				report := check(g)
+				assert.Equal(t, []uint64{num}, report.MissingHeaders)
+				assert.False(t, report.Complete())
+			})
+
+			t.Run("unused node", func(t *testing.T) {
+				g := decode()
+				ew := flatten(t, g, nil, nil)
+				var extra []byte
+				trie := mpt.New()
+				_, err := trie.Insert(keccak.Keccak([]byte("unused")).Bytes(), []byte("unused"))
+				require.NoError(t, err)
+				_, err = trie.Hash(func(node []byte) { extra = slices.Clone(node) })
+				require.NoError(t, err)
+				ew.State = append(ew.State, extra)
+				g.ExecutionWitness = ew
+				report := check(g)
+				assert.NoError(t, report.Err)
+				assert.True(t, report.Complete())
+				assert.Contains(t, report.UnusedNodes, keccak.Keccak(extra))
+			})
+		})
+	}
+}
+
+// flatten turns the tries of g into a flat witness, keeping the nodes of the
+// state and the storage tries accepted by keepState and keepStorage.
+func flatten(t *testing.T, g *witness.GuestInput, keepState, keepStorage func([]byte) bool) *witness.ExecutionWitness {
+	ew := &witness.ExecutionWitness{
+		Headers: append([]*types.Header{g.ParentHeader}, g.AncestorHeaders...),
+		Codes:   make([]hexutil.Bytes, len(g.Contracts)),
+	}
+	for i, code := range g.Contracts {
+		ew.Codes[i] = code
+	}
+	collect := func(keep func([]byte) bool) func([]byte) {
+		return func(node []byte) {
+			if keep == nil || keep(node) {
+				ew.State = append(ew.State, slices.Clone(node))
+			}
+		}
+	}
+	_, err := g.ParentStateTrie.Hash(collect(keepState))
+	require.NoError(t, err)
+	for _, storage := range g.ParentStorage {
+		_, err := storage.Trie.Hash(collect(keepStorage))
+		require.NoError(t, err)
+	}
+	return ew
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/gaiko/tests/fixtures"
+)
+
+func TestDiffExecutionWitness(t *testing.T) {
+	inputs, err := fixtures.GetSingleInputs()
+	require.NoError(t, err)
+	for id, input := range inputs {
+		t.Run(fmt.Sprintf("task: %d", id), func(t *testing.T) {
+			var a, b witness.GuestInput
+			require.NoError(t, json.Unmarshal(input.Input, &a))
+			require.NoError(t, json.Unmarshal(input.Input, &b))
+			wit, err := a.NewWitness()
+			require.NoError(t, err)
+			ew := &witness.ExecutionWitness{Headers: wit.Headers}
+			for node := range wit.State {
+				ew.State = append(ew.State, []byte(node))
+			}
+			a.ExecutionWitness = ew
+
+			diffs, err := witness.Diff(&a, &b)
+			require.NoError(t, err)
+			require.Len(t, diffs, 1)
+			assert.Equal(t, "execution_witness", diffs[0].Path)
+
+			// the order of the nodes doesn't matter
+			b.ExecutionWitness = &witness.ExecutionWitness{
+				Headers: ew.Headers,
+				State:   slices.Clone(ew.State),
+			}
+			slices.Reverse(b.ExecutionWitness.State)
+			diffs, err = witness.Diff(&a, &b)
+			require.NoError(t, err)
+			assert.Empty(t, diffs)
+
+			removed := b.ExecutionWitness.State[0]
+			added := hexutil.Bytes{0x80}
+			b.ExecutionWitness.State[0] = added
+			b.ExecutionWitness.Codes = []hexutil.Bytes{{0x00}}
+			b.ExecutionWitness.Headers = ew.Headers[:len(ew.Headers)-1]
+			diffs, err = witness.Diff(&a, &b)
+			require.NoError(t, err)
+			got := make([]string, 0, len(diffs))
+			for _, diff := range diffs {
+				got = append(got, diff.String())
+			}
+			assert.ElementsMatch(t, []string{
+				fmt.Sprintf("execution_witness.state: removed %#x", keccak.Keccak(removed)),
+				fmt.Sprintf("execution_witness.state: added %#x", keccak.Keccak(added)),
+				fmt.Sprintf("execution_witness.codes: added %#x", keccak.Keccak([]byte{0x00})),
+				fmt.Sprintf("execution_witness.headers: removed %#x", ew.Headers[len(ew.Headers)-1].Hash()),
+			}, got)
+		})
+	}
+}
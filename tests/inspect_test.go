@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/tests/fixtures"
+)
+
+func TestInspectBatch(t *testing.T) {
+	inputs, err := fixtures.GetBatchInputs()
+	require.NoError(t, err)
+
+	for id, input := range inputs {
+		if input.Input == nil {
+			continue
+		}
+		t.Run(fmt.Sprintf("task: %d", id), func(t *testing.T) {
+			var batch witness.BatchGuestInput
+			require.NoError(t, json.Unmarshal(input.Input, &batch))
+			summary, err := witness.Inspect(&batch, 3)
+			require.NoError(t, err)
+
+			assert.Equal(t, batch.Taiko.BatchID, summary.BatchID)
+			assert.Equal(t, batch.Taiko.ChainSpec.Name, summary.Chain)
+			require.Len(t, summary.Blocks, len(batch.Inputs))
+			assert.Len(t, summary.Blobs, len(batch.Taiko.TxDataFromBlob))
+			assert.LessOrEqual(t, len(summary.Accounts), 3)
+			for i, block := range summary.Blocks {
+				assert.Equal(t, batch.Inputs[i].Block.NumberU64(), block.Number)
+			}
+			for i := 1; i < len(summary.Accounts); i++ {
+				assert.GreaterOrEqual(t, summary.Accounts[i-1].Trie.Bytes, summary.Accounts[i].Trie.Bytes)
+			}
+
+			// accounts of the same size keep a stable order
+			again, err := witness.Inspect(&batch, 3)
+			require.NoError(t, err)
+			assert.Equal(t, summary.Accounts, again.Accounts)
+
+			_, err = witness.Inspect(&batch, -1)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/tests/fixtures"
+)
+
+func TestSingleInputRoundTrip(t *testing.T) {
+	inputs, err := fixtures.GetSingleInputs()
+	require.NoError(t, err)
+
+	for id, input := range inputs {
+		if input.Input == nil {
+			continue
+		}
+		t.Run(fmt.Sprintf("task: %d", id), func(t *testing.T) {
+			var want witness.GuestInput
+			require.NoError(t, json.Unmarshal(input.Input, &want))
+			data, err := json.Marshal(&want)
+			require.NoError(t, err)
+			// gaiko writes the fixture back the way raiko wrote it, with
+			// its schema version
+			assert.JSONEq(t, versioned(t, input.Input), string(data))
+			var got witness.GuestInput
+			require.NoError(t, json.Unmarshal(data, &got))
+
+			assertGuestInputEqual(t, &want, &got)
+			assertProposedEqual(t, want.Taiko.BlockProposed, got.Taiko.BlockProposed)
+			assert.Equal(t, want.Taiko.AnchorTx.Hash(), got.Taiko.AnchorTx.Hash())
+			assert.Equal(t, want.Taiko.TxData, got.Taiko.TxData)
+			assert.Equal(t, want.Taiko.L1Header.Hash(), got.Taiko.L1Header.Hash())
+
+			again, err := json.Marshal(&got)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(data), string(again))
+		})
+	}
+}
+
+func TestBatchInputRoundTrip(t *testing.T) {
+	inputs, err := fixtures.GetBatchInputs()
+	require.NoError(t, err)
+
+	for id, input := range inputs {
+		if input.Input == nil {
+			continue
+		}
+		t.Run(fmt.Sprintf("task: %d", id), func(t *testing.T) {
+			var want witness.BatchGuestInput
+			require.NoError(t, json.Unmarshal(input.Input, &want))
+			data, err := json.Marshal(&want)
+			require.NoError(t, err)
+			// gaiko writes the fixture back the way raiko wrote it, with
+			// its schema version
+			assert.JSONEq(t, versioned(t, input.Input), string(data))
+			var got witness.BatchGuestInput
+			require.NoError(t, json.Unmarshal(data, &got))
+
+			require.Len(t, got.Inputs, len(want.Inputs))
+			for i := range want.Inputs {
+				assertGuestInputEqual(t, want.Inputs[i], got.Inputs[i])
+			}
+			assert.Equal(t, want.Taiko.BatchID, got.Taiko.BatchID)
+			assert.Equal(t, want.Taiko.L1Header.Hash(), got.Taiko.L1Header.Hash())
+			assertProposedEqual(t, want.Taiko.BatchProposed, got.Taiko.BatchProposed)
+			assert.Equal(t, want.Taiko.TxDataFromCalldata, got.Taiko.TxDataFromCalldata)
+			assert.Equal(t, want.Taiko.TxDataFromBlob, got.Taiko.TxDataFromBlob)
+			assert.Equal(t, want.Taiko.BlobCommitments, got.Taiko.BlobCommitments)
+
+			again, err := json.Marshal(&got)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(data), string(again))
+		})
+	}
+}
+
+// versioned adds the schema version gaiko writes to a raiko witness.
+func versioned(t *testing.T, data []byte) string {
+	t.Helper()
+	var raw map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &raw))
+	require.NotContains(t, raw, "schema_version")
+	raw["schema_version"] = json.RawMessage(strconv.Itoa(int(witness.CurrentSchemaVersion)))
+	data, err := json.Marshal(raw)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func assertGuestInputEqual(t *testing.T, want, got *witness.GuestInput) {
+	t.Helper()
+	assert.Equal(t, want.Block.Hash(), got.Block.Hash())
+	assert.Equal(t, want.Block.Transactions().Len(), got.Block.Transactions().Len())
+	for i, tx := range want.Block.Transactions() {
+		assert.Equal(t, tx.Hash(), got.Block.Transactions()[i].Hash())
+	}
+	assert.Equal(t, want.ParentHeader.Hash(), got.ParentHeader.Hash())
+	require.Len(t, got.AncestorHeaders, len(want.AncestorHeaders))
+	for i, header := range want.AncestorHeaders {
+		assert.Equal(t, header.Hash(), got.AncestorHeaders[i].Hash())
+	}
+	assert.Equal(t, want.Contracts, got.Contracts)
+	assert.Equal(t, want.ChainSpec.HardForks, got.ChainSpec.HardForks)
+
+	wantRoot, err := want.ParentStateTrie.Hash()
+	require.NoError(t, err)
+	gotRoot, err := got.ParentStateTrie.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+
+	require.Len(t, got.ParentStorage, len(want.ParentStorage))
+	for addr, entry := range want.ParentStorage {
+		require.Contains(t, got.ParentStorage, addr)
+		wantRoot, err := entry.Trie.Hash()
+		require.NoError(t, err)
+		gotRoot, err := got.ParentStorage[addr].Trie.Hash()
+		require.NoError(t, err)
+		assert.Equal(t, wantRoot, gotRoot)
+		require.Len(t, got.ParentStorage[addr].Slots, len(entry.Slots))
+		for i, slot := range entry.Slots {
+			assert.Zero(t, slot.Cmp(got.ParentStorage[addr].Slots[i]))
+		}
+	}
+}
+
+func assertProposedEqual(t *testing.T, want, got witness.BlockProposedFork) {
+	t.Helper()
+	assert.Equal(t, want.HardFork(), got.HardFork())
+	wantEncoded, err := want.ABIEncode()
+	require.NoError(t, err)
+	gotEncoded, err := got.ABIEncode()
+	require.NoError(t, err)
+	assert.Equal(t, wantEncoded, gotEncoded)
+}
+
+// TestSingleInputBinary round-trips the fixtures through gaiko's binary
+// encoding.
+func TestSingleInputBinary(t *testing.T) {
+	inputs, err := fixtures.GetSingleInputs()
+	require.NoError(t, err)
+
+	for id, input := range inputs {
+		if input.Input == nil {
+			continue
+		}
+		t.Run(fmt.Sprintf("task: %d", id), func(t *testing.T) {
+			var want witness.GuestInput
+			require.NoError(t, json.Unmarshal(input.Input, &want))
+			data, err := want.MarshalBinary()
+			require.NoError(t, err)
+			var got witness.GuestInput
+			require.NoError(t, got.UnmarshalBinary(data))
+
+			assertGuestInputEqual(t, &want, &got)
+			assertJSONEqual(t, &want, &got)
+			again, err := got.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, data, again)
+		})
+	}
+}
+
+func TestBatchInputBinary(t *testing.T) {
+	inputs, err := fixtures.GetBatchInputs()
+	require.NoError(t, err)
+
+	for id, input := range inputs {
+		if input.Input == nil {
+			continue
+		}
+		t.Run(fmt.Sprintf("task: %d", id), func(t *testing.T) {
+			var want witness.BatchGuestInput
+			require.NoError(t, json.Unmarshal(input.Input, &want))
+			data, err := want.MarshalBinary()
+			require.NoError(t, err)
+			assert.Less(t, len(data), len(input.Input))
+			var got witness.BatchGuestInput
+			require.NoError(t, got.UnmarshalBinary(data))
+
+			require.Len(t, got.Inputs, len(want.Inputs))
+			for i := range want.Inputs {
+				assertGuestInputEqual(t, want.Inputs[i], got.Inputs[i])
+			}
+			assertJSONEqual(t, &want, &got)
+		})
+	}
+}
+
+func assertJSONEqual(t *testing.T, want, got any) {
+	t.Helper()
+	wantJSON, err := json.Marshal(want)
+	require.NoError(t, err)
+	gotJSON, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(wantJSON), string(gotJSON))
+}
@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/taikoxyz/gaiko/internal/transition"
+)
+
+func TestPipelineCancel(t *testing.T) {
+	errFirst := errors.New("first")
+	p := transition.NewPipeline(context.Background(), 2)
+	var ran atomic.Int32
+	assert.NoError(t, p.Go(func(context.Context) error {
+		ran.Add(1)
+		return errFirst
+	}))
+	for range 100 {
+		err := p.Go(func(ctx context.Context) error {
+			ran.Add(1)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		if err != nil {
+			assert.ErrorIs(t, err, context.Canceled)
+			break
+		}
+	}
+	assert.ErrorIs(t, p.Wait(), errFirst)
+	assert.Less(t, ran.Load(), int32(101))
+}
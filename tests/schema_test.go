@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/tests/fixtures"
+)
+
+func TestBatchSchema(t *testing.T) {
+	inputs, err := fixtures.GetBatchInputs()
+	require.NoError(t, err)
+
+	for id, input := range inputs {
+		if input.Input == nil {
+			continue
+		}
+		t.Run(fmt.Sprintf("task: %d", id), func(t *testing.T) {
+			var raw map[string]any
+			require.NoError(t, json.Unmarshal(input.Input, &raw))
+			taiko := raw["taiko"].(map[string]any)
+
+			// a v1 witness has no blob proof type
+			delete(taiko, "blob_proof_type")
+			data, err := json.Marshal(raw)
+			require.NoError(t, err)
+			var got witness.BatchGuestInput
+			require.NoError(t, json.Unmarshal(data, &got))
+			assert.Equal(t, witness.KzgVersionedHash, got.Taiko.BlobProofType)
+
+			raw["schema_version"] = 3
+			data, err = json.Marshal(raw)
+			require.NoError(t, err)
+			require.Error(t, json.Unmarshal(data, &got))
+
+			raw["schema_version"] = 2
+			taiko["unknown"] = true
+			delete(taiko, "batch_id")
+			// the proposal is checked against the type of its fork
+			proposed := taiko["batch_proposed"].(map[string]any)["Pacaya"].(map[string]any)
+			proposed["unknown"] = true
+			delete(proposed["meta"].(map[string]any), "infoHash")
+			data, err = json.Marshal(raw)
+			require.NoError(t, err)
+			var schemaErr *witness.SchemaError
+			require.ErrorAs(t, witness.Validate(data, &got), &schemaErr)
+			var paths []string
+			for _, v := range schemaErr.Violations {
+				paths = append(paths, v.Path)
+			}
+			assert.Contains(t, paths, "taiko.unknown")
+			assert.Contains(t, paths, "taiko.batch_id")
+			assert.Contains(t, paths, "taiko.blob_proof_type")
+			assert.Contains(t, paths, "taiko.batch_proposed.Pacaya.unknown")
+			assert.Contains(t, paths, "taiko.batch_proposed.Pacaya.meta.infoHash")
+			assert.NotContains(t, paths, "schema_version")
+
+			// the version must precede the parts it applies to
+			data = bytes.TrimSpace(input.Input)
+			first := append([]byte(`{"schema_version":2,`), data[1:]...)
+			require.NoError(t, json.Unmarshal(first, &got))
+			last := append(data[:len(data)-1:len(data)-1], []byte(`,"schema_version":2}`)...)
+			assert.ErrorContains(t, json.Unmarshal(last, &got), "schema_version must come before")
+
+			// gaiko writes the version first, so that it applies when streamed
+			require.NoError(t, json.Unmarshal(input.Input, &got))
+			data, err = json.Marshal(&got)
+			require.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(data, []byte(`{"schema_version":2,`)))
+		})
+	}
+}
@@ -53,3 +53,46 @@ func TestSingle(t *testing.T) {
 		})
 	}
 }
+
+func TestSingleExecutionWitness(t *testing.T) {
+	inputs, err := fixtures.GetSingleInputs()
+	require.NoError(t, err)
+
+	for id, input := range inputs {
+		t.Run(fmt.Sprintf("task: %d", id), func(t *testing.T) {
+			// replace the tries by the flat witness they produce
+			var g witness.GuestInput
+			require.NoError(t, json.Unmarshal(input.Input, &g))
+			wit, err := g.NewWitness()
+			require.NoError(t, err)
+			ew := &witness.ExecutionWitness{Headers: wit.Headers}
+			for node := range wit.State {
+				ew.State = append(ew.State, []byte(node))
+			}
+			for code := range wit.Codes {
+				ew.Codes = append(ew.Codes, []byte(code))
+			}
+			g.ParentStateTrie = nil
+			g.ParentStorage = nil
+			g.Contracts = nil
+			g.ExecutionWitness = ew
+			data, err := json.Marshal(&g)
+			require.NoError(t, err)
+
+			var output prover.ProofResponse
+			var b bytes.Buffer
+			args := &flags.Arguments{
+				SGXType:       "debug",
+				ProofType:     witness.NativeProofType,
+				WitnessReader: bytes.NewReader(data),
+				ProofWriter:   &b,
+			}
+			require.NoError(t, prover.NewSGXProver(args).Oneshot(context.Background(), args))
+			require.NoError(t, json.NewDecoder(&b).Decode(&output))
+
+			var expectedOutput SingleGuestOutput
+			require.NoError(t, json.Unmarshal(input.Output, &expectedOutput))
+			assert.Equal(t, expectedOutput.Hash, output.Input)
+		})
+	}
+}
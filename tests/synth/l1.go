@@ -0,0 +1,161 @@
+package synth
+
+import (
+	"cmp"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+)
+
+const (
+	// The defaults are a Cancun block of mainnet, Prague blocks would need the
+	// system contracts of the requests in the state.
+	defaultL1Number   = 20_000_000
+	defaultL1Time     = 1_720_000_000
+	defaultL1GasLimit = 30_000_000
+)
+
+// L1Config describes an Ethereum block, the zero values of its fields have
+// defaults.
+type L1Config struct {
+	// Alloc is the state before the block.
+	Alloc    types.GenesisAlloc
+	Number   uint64
+	Time     uint64
+	Txs      []*types.Transaction
+	Coinbase common.Address
+}
+
+// L1Block is a generated Ethereum block witness with what proving it must give.
+type L1Block struct {
+	Input *witness.L1BlockGuestInput
+	// Output is the public input hash of the block, as the native prover
+	// gives it in debug mode: no verifier contract and no instance.
+	Output   common.Hash
+	Block    *types.Block
+	Receipts types.Receipts
+	// Dropped are the transactions left out of the block.
+	Dropped []*types.Transaction
+}
+
+// GenerateL1 executes the transactions of cfg in a mainnet block on top of its
+// state and builds the witness of the block.
+func GenerateL1(cfg *L1Config) (*L1Block, error) {
+	spec, err := witness.LookupChainSpec(witness.EthereumNetwork)
+	if err != nil {
+		return nil, err
+	}
+	chainConfig, err := (&witness.GuestInput{ChainSpec: spec}).ChainConfig()
+	if err != nil {
+		return nil, err
+	}
+	db := rawdb.NewMemoryDatabase()
+	tdb := triedb.NewDatabase(db, triedb.HashDefaults)
+	g := &generator{
+		cfg:         &Config{ChainSpec: spec, Alloc: cfg.Alloc, Coinbase: cfg.Coinbase},
+		spec:        spec,
+		chainConfig: chainConfig,
+		db:          db,
+		tdb:         tdb,
+		sdb:         state.NewDatabase(tdb, nil),
+		addrs:       map[common.Hash]common.Address{},
+		headers:     map[common.Hash]*types.Header{},
+	}
+	return g.generateL1(cmp.Or(cfg.Number, defaultL1Number), cmp.Or(cfg.Time, defaultL1Time), cfg.Txs)
+}
+
+func (g *generator) generateL1(number, time uint64, txs []*types.Transaction) (*L1Block, error) {
+	statedb, err := g.alloc(g.cfg.Alloc)
+	if err != nil {
+		return nil, err
+	}
+	parent := l1Header(number-1, time-12)
+	parent.ParentHash = keccak.Keccak([]byte("synth l1 grandparent"))
+	parent.TxHash = types.EmptyTxsHash
+	parent.ReceiptHash = types.EmptyReceiptsHash
+	if parent.Root, err = g.commit(statedb, parent); err != nil {
+		return nil, err
+	}
+	g.headers[parent.Hash()] = parent
+
+	header := l1Header(number, time)
+	header.ParentHash = parent.Hash()
+	header.Coinbase = g.cfg.Coinbase
+	if statedb, err = state.New(parent.Root, g.sdb); err != nil {
+		return nil, err
+	}
+	// the beacon roots contract isn't in the state unless alloc has it, the
+	// call is made anyway like geth does
+	vmContext := core.NewEVMBlockContext(header, g, &header.Coinbase)
+	evm := vm.NewEVM(vmContext, statedb, g.chainConfig, vm.Config{})
+	core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, evm)
+	signer := types.MakeSigner(g.chainConfig, header.Number, header.Time)
+	included, receipts, dropped, err := g.apply(statedb, header, signer, nil, txs)
+	if err != nil {
+		return nil, err
+	}
+	block, receipts, dropped, err := g.seal(statedb, header, included, receipts, dropped)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := g.guestInput(block, []*types.Header{parent})
+	if err != nil {
+		return nil, err
+	}
+	output, err := l1PublicInputHash(g.spec.ChainID, block)
+	if err != nil {
+		return nil, err
+	}
+	return &L1Block{
+		Input:    &witness.L1BlockGuestInput{GuestInput: *input},
+		Output:   output,
+		Block:    block,
+		Receipts: receipts,
+		Dropped:  dropped,
+	}, nil
+}
+
+// l1Header is a post-merge header without blobs.
+func l1Header(number, time uint64) *types.Header {
+	beaconRoot := keccak.Keccak(new(big.Int).SetUint64(number).Bytes())
+	return &types.Header{
+		UncleHash:        types.EmptyUncleHash,
+		Difficulty:       common.Big0,
+		Number:           new(big.Int).SetUint64(number),
+		GasLimit:         defaultL1GasLimit,
+		Time:             time,
+		BaseFee:          big.NewInt(params.GWei),
+		WithdrawalsHash:  &types.EmptyWithdrawalsHash,
+		BlobGasUsed:      new(uint64),
+		ExcessBlobGas:    new(uint64),
+		ParentBeaconRoot: &beaconRoot,
+	}
+}
+
+// l1PublicInputHash hashes the public input of an Ethereum block without a
+// verifier contract or an instance.
+func l1PublicInputHash(chainID uint64, block *types.Block) (common.Hash, error) {
+	data, err := l1PublicInputArgs.Pack(
+		"VERIFY_PROOF",
+		chainID,
+		common.Address{},
+		[32]byte(block.ParentHash()),
+		[32]byte(block.Hash()),
+		[32]byte(block.Root()),
+		common.Address{},
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return keccak.Keccak(data), nil
+}
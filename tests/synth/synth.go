@@ -0,0 +1,765 @@
+// Package synth generates Pacaya batch witnesses from a genesis state and the
+// transactions of the blocks, so that tests can cover blocks no chain has. It
+// generates Ethereum block witnesses the same way.
+//
+// The blocks are executed like taiko-geth builds them: an anchor from the
+// golden touch account first, then the proposed transactions that can be
+// applied. The witness carries the whole parent state of every block.
+package synth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/pkg/keccak"
+	"github.com/taikoxyz/gaiko/pkg/mpt"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/utils"
+)
+
+const (
+	anchorGasLimit  = 1_000_000
+	defaultGasLimit = 240_000_000
+	// maxAncestors is how far back BLOCKHASH reaches.
+	maxAncestors = 256
+)
+
+var (
+	// goldenTouchKey is the well-known key that signs the anchors of Taiko.
+	goldenTouchKey, _ = crypto.HexToECDSA("92954368afd3caa1f3ce3ead0069c1af414054aefe1ef9aeacc1bf426222ce38")
+	GoldenTouch       = crypto.PubkeyToAddress(goldenTouchKey.PublicKey)
+
+	DefaultBaseFee       = big.NewInt(params.GWei / 100)
+	DefaultBaseFeeConfig = pacaya.LibSharedDataBaseFeeConfig{
+		AdjustmentQuotient:     8,
+		SharingPctg:            50,
+		GasIssuancePerSecond:   5_000_000,
+		MinGasExcess:           1_340_000_000,
+		MaxGasIssuancePerBlock: 600_000_000,
+	}
+)
+
+// Block is a block to generate.
+type Block struct {
+	// Txs are the transactions proposed for the block. Those that can't be
+	// applied are left out of the block, like taiko-geth does, but stay in
+	// the tx list of the batch.
+	Txs []*types.Transaction
+	// TimeShift is the time since the previous block, the first block comes
+	// a second after the genesis.
+	TimeShift uint8
+}
+
+// Config describes a batch, the zero values of its fields have defaults.
+type Config struct {
+	// ChainSpec is taiko_hoodi if nil, the chain where every fork up to
+	// Pacaya is active from genesis.
+	ChainSpec *witness.ChainSpec
+	// Alloc is the state before the first block.
+	Alloc types.GenesisAlloc
+	// Time is the timestamp of the genesis.
+	Time   uint64
+	Blocks []*Block
+
+	BatchID       uint64
+	Coinbase      common.Address
+	BaseFee       *big.Int
+	BaseFeeConfig *pacaya.LibSharedDataBaseFeeConfig
+	// GasLimit is the gas limit of the batch, the blocks get the gas of the
+	// anchor on top.
+	GasLimit   uint32
+	ProverData witness.TaikoProverData
+	// Blob posts the tx list in blobs instead of calldata.
+	Blob          bool
+	BlobProofType witness.BlobProofType
+}
+
+// Batch is a generated witness with what proving it must give.
+type Batch struct {
+	Input *witness.BatchGuestInput
+	// Output is the public input hash of the batch, as the native prover
+	// gives it in debug mode: no verifier contract and no instance. It's
+	// encoded like TaikoInbox and the SGX verifier do, apart from gaiko.
+	Output   common.Hash
+	Blocks   []*types.Block
+	Receipts []types.Receipts
+	// Dropped are the proposed transactions left out of the blocks.
+	Dropped []*types.Transaction
+}
+
+// Generate executes the blocks of cfg on its genesis state and builds the
+// witness of the batch that proposes them.
+func Generate(cfg *Config) (*Batch, error) {
+	if len(cfg.Blocks) == 0 {
+		return nil, errors.New("no blocks")
+	}
+	spec := cfg.ChainSpec
+	if spec == nil {
+		var err error
+		if spec, err = witness.LookupChainSpec(witness.TaikoHoodiNetwork); err != nil {
+			return nil, err
+		}
+	}
+	if spec.L2Contract == nil {
+		return nil, fmt.Errorf("no L2 contract for %s", spec.Name)
+	}
+	chainConfig, err := (&witness.BatchGuestInput{
+		Taiko: &witness.TaikoGuestBatchInput{ChainSpec: spec},
+	}).ChainConfig()
+	if err != nil {
+		return nil, err
+	}
+	db := rawdb.NewMemoryDatabase()
+	tdb := triedb.NewDatabase(db, triedb.HashDefaults)
+	g := &generator{
+		cfg:           cfg,
+		spec:          spec,
+		chainConfig:   chainConfig,
+		db:            db,
+		tdb:           tdb,
+		sdb:           state.NewDatabase(tdb, nil),
+		baseFee:       cfg.BaseFee,
+		baseFeeConfig: cfg.BaseFeeConfig,
+		gasLimit:      cfg.GasLimit,
+		addrs:         map[common.Hash]common.Address{},
+		headers:       map[common.Hash]*types.Header{},
+	}
+	if g.baseFee == nil {
+		g.baseFee = DefaultBaseFee
+	}
+	if g.baseFeeConfig == nil {
+		g.baseFeeConfig = &DefaultBaseFeeConfig
+	}
+	if g.gasLimit == 0 {
+		g.gasLimit = defaultGasLimit
+	}
+	return g.generate()
+}
+
+type generator struct {
+	cfg           *Config
+	spec          *witness.ChainSpec
+	chainConfig   *params.ChainConfig
+	db            ethdb.Database
+	tdb           *triedb.Database
+	sdb           state.Database
+	baseFee       *big.Int
+	baseFeeConfig *pacaya.LibSharedDataBaseFeeConfig
+	gasLimit      uint32
+	// addrs are the preimages of the account keys, to find the address of
+	// the storage tries.
+	addrs   map[common.Hash]common.Address
+	headers map[common.Hash]*types.Header
+}
+
+func (g *generator) generate() (*Batch, error) {
+	genesis, err := g.genesis()
+	if err != nil {
+		return nil, err
+	}
+	l1 := &types.Header{
+		UncleHash:  types.EmptyUncleHash,
+		Root:       keccak.Keccak([]byte("synth l1 state")),
+		TxHash:     types.EmptyTxsHash,
+		Difficulty: common.Big0,
+		Number:     big.NewInt(100),
+		GasLimit:   30_000_000,
+		Time:       g.cfg.Time,
+		BaseFee:    big.NewInt(params.GWei),
+	}
+
+	batch := &Batch{}
+	parent := genesis
+	chain := []*types.Header{genesis}
+	for i, b := range g.cfg.Blocks {
+		shift := uint64(b.TimeShift)
+		if i == 0 {
+			shift = 1
+		}
+		block, receipts, dropped, err := g.block(parent, l1, b.Txs, parent.Time+shift)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+		batch.Blocks = append(batch.Blocks, block)
+		batch.Receipts = append(batch.Receipts, receipts)
+		batch.Dropped = append(batch.Dropped, dropped...)
+		parent = block.Header()
+		chain = append(chain, parent)
+	}
+
+	taiko, err := g.taikoInput(l1, batch.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	fork := taiko.BatchProposed.(*witness.PacayaBlockProposed)
+	batch.Input = &witness.BatchGuestInput{Taiko: taiko}
+	for i, block := range batch.Blocks {
+		input, err := g.guestInput(block, chain[max(0, i+1-maxAncestors):i+1])
+		if err != nil {
+			return nil, err
+		}
+		input.Taiko = &witness.TaikoGuestInput{
+			L1Header:      l1,
+			TxData:        []byte{},
+			AnchorTx:      block.Transactions()[0],
+			BlockProposed: fork,
+			ProverData:    taiko.ProverData,
+			BlobProofType: taiko.BlobProofType,
+		}
+		batch.Input.Inputs = append(batch.Input.Inputs, input)
+	}
+
+	first, last := batch.Blocks[0], batch.Blocks[len(batch.Blocks)-1]
+	batch.Output, err = publicInputHash(g.spec.ChainID, &pacaya.ITaikoInboxTransition{
+		ParentHash: first.ParentHash(),
+		BlockHash:  last.Hash(),
+		StateRoot:  last.Root(),
+	}, &fork.Meta)
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+func (g *generator) genesis() (*types.Header, error) {
+	statedb, err := g.alloc(g.cfg.Alloc)
+	if err != nil {
+		return nil, err
+	}
+	header := &types.Header{
+		UncleHash:       types.EmptyUncleHash,
+		TxHash:          types.EmptyTxsHash,
+		ReceiptHash:     types.EmptyReceiptsHash,
+		Difficulty:      common.Big0,
+		Number:          common.Big0,
+		GasLimit:        uint64(g.gasLimit) + anchorGasLimit,
+		Time:            g.cfg.Time,
+		Extra:           g.extraData(),
+		BaseFee:         g.baseFee,
+		WithdrawalsHash: &types.EmptyWithdrawalsHash,
+	}
+	if header.Root, err = g.commit(statedb, header); err != nil {
+		return nil, err
+	}
+	g.headers[header.Hash()] = header
+	return header, nil
+}
+
+// alloc sets up a state with the accounts of alloc.
+func (g *generator) alloc(alloc types.GenesisAlloc) (*state.StateDB, error) {
+	statedb, err := state.New(types.EmptyRootHash, g.sdb)
+	if err != nil {
+		return nil, err
+	}
+	for addr, acc := range alloc {
+		g.known(addr)
+		statedb.SetCode(addr, acc.Code)
+		statedb.SetNonce(addr, acc.Nonce, tracing.NonceChangeGenesis)
+		if acc.Balance != nil {
+			statedb.SetBalance(addr, uint256.MustFromBig(acc.Balance), tracing.BalanceIncreaseGenesisBalance)
+		}
+		for key, value := range acc.Storage {
+			statedb.SetState(addr, key, value)
+		}
+	}
+	return statedb, nil
+}
+
+// block executes txs on the state of parent, the anchor first.
+func (g *generator) block(
+	parent, l1 *types.Header,
+	txs []*types.Transaction,
+	time uint64,
+) (*types.Block, types.Receipts, []*types.Transaction, error) {
+	header := &types.Header{
+		ParentHash:      parent.Hash(),
+		UncleHash:       types.EmptyUncleHash,
+		Coinbase:        g.cfg.Coinbase,
+		Difficulty:      common.Big0,
+		Number:          new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:        uint64(g.gasLimit) + anchorGasLimit,
+		Time:            time,
+		Extra:           g.extraData(),
+		BaseFee:         g.baseFee,
+		WithdrawalsHash: &types.EmptyWithdrawalsHash,
+	}
+	statedb, err := state.New(parent.Root, g.sdb)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	signer := types.MakeSigner(g.chainConfig, header.Number, header.Time)
+	anchor, err := g.anchorTx(statedb, signer, header, parent, l1)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	included, receipts, dropped, err := g.apply(statedb, header, signer, anchor, txs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return g.seal(statedb, header, included, receipts, dropped)
+}
+
+// apply executes txs on statedb like a block builder, those that can't be
+// applied are dropped. The anchor, if any, goes first and must be applied.
+func (g *generator) apply(
+	statedb *state.StateDB,
+	header *types.Header,
+	signer types.Signer,
+	anchor *types.Transaction,
+	txs []*types.Transaction,
+) (types.Transactions, types.Receipts, []*types.Transaction, error) {
+	if anchor != nil {
+		txs = append([]*types.Transaction{anchor}, txs...)
+	}
+	var (
+		vmContext = core.NewEVMBlockContext(header, g, &header.Coinbase)
+		rules     = g.chainConfig.Rules(header.Number, true, header.Time)
+		gasPool   = new(core.GasPool).AddGas(header.GasLimit)
+		gasUsed   uint64
+		included  types.Transactions
+		receipts  types.Receipts
+		dropped   []*types.Transaction
+	)
+	for i, tx := range txs {
+		isAnchor := anchor != nil && i == 0
+		if isAnchor {
+			if err := tx.MarkAsAnchor(); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil || tx.Type() == types.BlobTxType {
+			dropped = append(dropped, tx)
+			continue
+		}
+		statedb.Prepare(rules, from, header.Coinbase, tx.To(), vm.ActivePrecompiles(rules), tx.AccessList())
+		statedb.SetTxContext(tx.Hash(), len(included))
+		var (
+			snapshot = statedb.Snapshot()
+			prevGas  = gasPool.Gas()
+		)
+		evm := vm.NewEVM(vmContext, statedb, g.chainConfig, vm.Config{})
+		receipt, err := core.ApplyTransaction(evm, gasPool, statedb, header, tx, &gasUsed)
+		if err != nil {
+			if isAnchor {
+				return nil, nil, nil, fmt.Errorf("anchor: %w", err)
+			}
+			statedb.RevertToSnapshot(snapshot)
+			gasPool.SetGas(prevGas)
+			dropped = append(dropped, tx)
+			continue
+		}
+		included = append(included, tx)
+		receipts = append(receipts, receipt)
+		g.known(from)
+		if to := tx.To(); to != nil {
+			g.known(*to)
+		}
+		if receipt.ContractAddress != (common.Address{}) {
+			g.known(receipt.ContractAddress)
+		}
+	}
+	header.GasUsed = gasUsed
+	return included, receipts, dropped, nil
+}
+
+// seal commits the state of a block executed by apply and builds it.
+func (g *generator) seal(
+	statedb *state.StateDB,
+	header *types.Header,
+	included types.Transactions,
+	receipts types.Receipts,
+	dropped []*types.Transaction,
+) (*types.Block, types.Receipts, []*types.Transaction, error) {
+	var err error
+	if header.Root, err = g.commit(statedb, header); err != nil {
+		return nil, nil, nil, err
+	}
+	block := types.NewBlock(
+		header,
+		&types.Body{Transactions: included, Withdrawals: []*types.Withdrawal{}},
+		receipts,
+		trie.NewStackTrie(nil),
+	)
+	g.headers[block.Hash()] = block.Header()
+	return block, receipts, dropped, nil
+}
+
+// anchorTx signs the anchorV3 call of a block.
+func (g *generator) anchorTx(
+	statedb *state.StateDB,
+	signer types.Signer,
+	header, parent, l1 *types.Header,
+) (*types.Transaction, error) {
+	data, err := encoding.TaikoAnchorABI.Pack(
+		"anchorV3",
+		l1.Number.Uint64(),
+		[32]byte(l1.Root),
+		uint32(parent.GasUsed),
+		*g.baseFeeConfig,
+		[][32]byte{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return types.SignNewTx(goldenTouchKey, signer, &types.DynamicFeeTx{
+		ChainID:   g.chainConfig.ChainID,
+		Nonce:     statedb.GetNonce(GoldenTouch),
+		GasTipCap: common.Big0,
+		GasFeeCap: header.BaseFee,
+		Gas:       anchorGasLimit,
+		To:        g.spec.L2Contract,
+		Data:      data,
+	})
+}
+
+// extraData carries the share of the base fee that goes to the coinbase.
+func (g *generator) extraData() []byte {
+	return common.BigToHash(big.NewInt(int64(g.baseFeeConfig.SharingPctg))).Bytes()
+}
+
+func (g *generator) commit(statedb *state.StateDB, header *types.Header) (common.Hash, error) {
+	root, err := statedb.Commit(
+		header.Number.Uint64(),
+		g.chainConfig.IsEIP158(header.Number),
+		g.chainConfig.IsCancun(header.Number, header.Time),
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return root, g.tdb.Commit(root, false)
+}
+
+func (g *generator) known(addr common.Address) {
+	g.addrs[keccak.Keccak(addr.Bytes())] = addr
+}
+
+// taikoInput posts the proposed transactions of all the blocks in calldata or
+// blobs, and builds the BatchProposed event of the batch like TaikoInbox emits
+// it.
+func (g *generator) taikoInput(l1 *types.Header, blocks []*types.Block) (*witness.TaikoGuestBatchInput, error) {
+	var (
+		txs         types.Transactions
+		blockParams []pacaya.ITaikoInboxBlockParams
+	)
+	for i, b := range g.cfg.Blocks {
+		txs = append(txs, b.Txs...)
+		shift := b.TimeShift
+		if i == 0 {
+			shift = 0
+		}
+		blockParams = append(blockParams, pacaya.ITaikoInboxBlockParams{
+			NumTransactions: uint16(len(b.Txs)),
+			TimeShift:       shift,
+			SignalSlots:     [][32]byte{},
+		})
+	}
+	encoded, err := rlp.EncodeToBytes(txs)
+	if err != nil {
+		return nil, err
+	}
+	txList, err := utils.Compress(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	proposedIn := l1.Number.Uint64() + 1
+	taiko := &witness.TaikoGuestBatchInput{
+		BatchID:            g.cfg.BatchID,
+		L1Header:           l1,
+		ChainSpec:          g.spec,
+		ProverData:         &g.cfg.ProverData,
+		TxDataFromCalldata: txList,
+		TxDataFromBlob:     [][eth.BlobSize]byte{},
+		BlobProofType:      g.cfg.BlobProofType,
+	}
+	if taiko.BlobProofType == "" {
+		taiko.BlobProofType = witness.KzgVersionedHash
+	}
+	event := &pacaya.TaikoInboxClientBatchProposed{TxList: txList}
+	info := &event.Info
+	info.BlobHashes = [][32]byte{}
+	if g.cfg.Blob {
+		if err := postBlobs(taiko, txList); err != nil {
+			return nil, err
+		}
+		for _, commitment := range *taiko.BlobCommitments {
+			info.BlobHashes = append(info.BlobHashes, eth.KZGToVersionedHash(commitment))
+		}
+		taiko.TxDataFromCalldata = []byte{}
+		event.TxList = nil
+		info.BlobByteSize = uint32(len(txList))
+		info.BlobCreatedIn = proposedIn
+	}
+	if info.TxsHash, err = txsHash(keccak.Keccak(taiko.TxDataFromCalldata), info.BlobHashes); err != nil {
+		return nil, err
+	}
+	last := blocks[len(blocks)-1]
+	info.Blocks = blockParams
+	info.ExtraData = [32]byte(g.extraData())
+	info.Coinbase = g.cfg.Coinbase
+	info.ProposedIn = proposedIn
+	info.GasLimit = g.gasLimit
+	info.LastBlockId = last.NumberU64()
+	info.LastBlockTimestamp = last.Time()
+	info.AnchorBlockId = l1.Number.Uint64()
+	info.AnchorBlockHash = l1.Hash()
+	info.BaseFeeConfig = *g.baseFeeConfig
+	data, err := batchInfoArgs.Pack(info)
+	if err != nil {
+		return nil, err
+	}
+	event.Meta = pacaya.ITaikoInboxBatchMetadata{
+		InfoHash:   keccak.Keccak(data),
+		Proposer:   g.cfg.Coinbase,
+		BatchId:    g.cfg.BatchID,
+		ProposedAt: l1.Time + 12,
+	}
+	taiko.BatchProposed = witness.NewPacayaBlockProposed(event)
+	return taiko, nil
+}
+
+// postBlobs splits the tx list over as many blobs as it needs, with their
+// commitments and proofs.
+func postBlobs(taiko *witness.TaikoGuestBatchInput, txList []byte) error {
+	var commitments, proofs [][48]byte
+	for data := txList; len(data) != 0; {
+		n := min(len(data), eth.MaxBlobDataSize)
+		var blob eth.Blob
+		if err := blob.FromData(data[:n]); err != nil {
+			return err
+		}
+		commitment, err := blob.ComputeKZGCommitment()
+		if err != nil {
+			return err
+		}
+		proof, err := kzg4844.ComputeBlobProof(blob.KZGBlob(), commitment)
+		if err != nil {
+			return err
+		}
+		taiko.TxDataFromBlob = append(taiko.TxDataFromBlob, [eth.BlobSize]byte(blob))
+		commitments = append(commitments, commitment)
+		proofs = append(proofs, proof)
+		data = data[n:]
+	}
+	taiko.BlobCommitments = &commitments
+	taiko.BlobProofs = &proofs
+	return nil
+}
+
+// The types of ITaikoInbox and of the public input, written out from the
+// contracts rather than taken from gaiko.
+var (
+	stringType, _       = abi.NewType("string", "", nil)
+	uint64Type, _       = abi.NewType("uint64", "", nil)
+	addressType, _      = abi.NewType("address", "", nil)
+	bytes32Type, _      = abi.NewType("bytes32", "", nil)
+	bytes32ArrayType, _ = abi.NewType("bytes32[]", "", nil)
+	txsHashArgs         = abi.Arguments{{Type: bytes32Type}, {Type: bytes32ArrayType}}
+
+	batchInfoType, _ = abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "txsHash", Type: "bytes32"},
+		{Name: "blocks", Type: "tuple[]", Components: []abi.ArgumentMarshaling{
+			{Name: "numTransactions", Type: "uint16"},
+			{Name: "timeShift", Type: "uint8"},
+			{Name: "signalSlots", Type: "bytes32[]"},
+		}},
+		{Name: "blobHashes", Type: "bytes32[]"},
+		{Name: "extraData", Type: "bytes32"},
+		{Name: "coinbase", Type: "address"},
+		{Name: "proposedIn", Type: "uint64"},
+		{Name: "blobCreatedIn", Type: "uint64"},
+		{Name: "blobByteOffset", Type: "uint32"},
+		{Name: "blobByteSize", Type: "uint32"},
+		{Name: "gasLimit", Type: "uint32"},
+		{Name: "lastBlockId", Type: "uint64"},
+		{Name: "lastBlockTimestamp", Type: "uint64"},
+		{Name: "anchorBlockId", Type: "uint64"},
+		{Name: "anchorBlockHash", Type: "bytes32"},
+		{Name: "baseFeeConfig", Type: "tuple", Components: []abi.ArgumentMarshaling{
+			{Name: "adjustmentQuotient", Type: "uint8"},
+			{Name: "sharingPctg", Type: "uint8"},
+			{Name: "gasIssuancePerSecond", Type: "uint32"},
+			{Name: "minGasExcess", Type: "uint64"},
+			{Name: "maxGasIssuancePerBlock", Type: "uint32"},
+		}},
+	})
+	batchMetadataType, _ = abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "infoHash", Type: "bytes32"},
+		{Name: "proposer", Type: "address"},
+		{Name: "batchId", Type: "uint64"},
+		{Name: "proposedAt", Type: "uint64"},
+	})
+	transitionType, _ = abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "parentHash", Type: "bytes32"},
+		{Name: "blockHash", Type: "bytes32"},
+		{Name: "stateRoot", Type: "bytes32"},
+	})
+	// batchInfoArgs and batchMetadataArgs are abi.encode of the structs, the
+	// way TaikoInbox hashes them.
+	batchInfoArgs     = abi.Arguments{{Type: batchInfoType}}
+	batchMetadataArgs = abi.Arguments{{Type: batchMetadataType}}
+	// publicInputArgs are those of LibPublicInput.hashPublicInputs.
+	publicInputArgs = abi.Arguments{
+		{Type: stringType},
+		{Type: uint64Type},
+		{Type: addressType},
+		{Type: transitionType},
+		{Type: addressType},
+		{Type: bytes32Type},
+	}
+	// l1PublicInputArgs are those of an Ethereum block, with the transition
+	// flattened.
+	l1PublicInputArgs = abi.Arguments{
+		{Type: stringType},
+		{Type: uint64Type},
+		{Type: addressType},
+		{Type: bytes32Type},
+		{Type: bytes32Type},
+		{Type: bytes32Type},
+		{Type: addressType},
+	}
+)
+
+// publicInputHash hashes the public input of a batch without a verifier
+// contract or an instance.
+func publicInputHash(
+	chainID uint64,
+	transition *pacaya.ITaikoInboxTransition,
+	meta *pacaya.ITaikoInboxBatchMetadata,
+) (common.Hash, error) {
+	data, err := batchMetadataArgs.Pack(meta)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	data, err = publicInputArgs.Pack(
+		"VERIFY_PROOF",
+		chainID,
+		common.Address{},
+		transition,
+		common.Address{},
+		[32]byte(keccak.Keccak(data)),
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return keccak.Keccak(data), nil
+}
+
+func txsHash(txListHash common.Hash, blobHashes [][32]byte) (common.Hash, error) {
+	data, err := txsHashArgs.Pack([32]byte(txListHash), blobHashes)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return keccak.Keccak(data), nil
+}
+
+// guestInput builds the input of block, headers end with its parent and go
+// back as far as BLOCKHASH reaches.
+func (g *generator) guestInput(block *types.Block, headers []*types.Header) (*witness.GuestInput, error) {
+	parent := headers[len(headers)-1]
+	stateTrie, err := g.trie(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	input := &witness.GuestInput{
+		Block:           block,
+		ChainSpec:       g.spec,
+		ParentHeader:    parent,
+		ParentStateTrie: stateTrie,
+		ParentStorage:   map[common.Address]*witness.StorageEntry{},
+		Contracts:       [][]byte{},
+		AncestorHeaders: []*types.Header{},
+	}
+	for i := len(headers) - 2; i >= 0; i-- {
+		input.AncestorHeaders = append(input.AncestorHeaders, headers[i])
+	}
+	codes := map[common.Hash]struct{}{}
+	for key, value := range stateTrie.Leaves(nil) {
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(value, &acc); err != nil {
+			return nil, err
+		}
+		if codeHash := common.BytesToHash(acc.CodeHash); codeHash != types.EmptyCodeHash {
+			if _, ok := codes[codeHash]; !ok {
+				code := rawdb.ReadCode(g.db, codeHash)
+				if len(code) == 0 {
+					return nil, fmt.Errorf("missing code %#x", codeHash)
+				}
+				codes[codeHash] = struct{}{}
+				input.Contracts = append(input.Contracts, code)
+			}
+		}
+		if acc.Root == types.EmptyRootHash {
+			continue
+		}
+		addr, ok := g.addrs[common.BytesToHash(key)]
+		if !ok {
+			return nil, fmt.Errorf("unknown address of the storage of account %#x", key)
+		}
+		storage, err := g.trie(acc.Root)
+		if err != nil {
+			return nil, err
+		}
+		input.ParentStorage[addr] = &witness.StorageEntry{Trie: storage, Slots: []*big.Int{}}
+	}
+	return input, nil
+}
+
+// trie resolves the whole trie of root from the database.
+func (g *generator) trie(root common.Hash) (*mpt.MptNode, error) {
+	trie, err := mpt.FromProofs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := trie.Resolve(nodeStore{g.db}); err != nil {
+		return nil, err
+	}
+	return trie, nil
+}
+
+type nodeStore struct {
+	db ethdb.KeyValueReader
+}
+
+func (s nodeStore) Node(hash common.Hash) ([]byte, bool) {
+	node := rawdb.ReadLegacyTrieNode(s.db, hash)
+	return node, len(node) != 0
+}
+
+// Engine and GetHeader make the generator the chain of the EVM, for BLOCKHASH.
+func (g *generator) Engine() consensus.Engine {
+	return beacon.New(ethash.NewFaker())
+}
+
+func (g *generator) GetHeader(hash common.Hash, _ uint64) *types.Header {
+	return g.headers[hash]
+}
+
+func (g *generator) Config() *params.ChainConfig {
+	return g.chainConfig
+}
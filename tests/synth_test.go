@@ -0,0 +1,275 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/taikoxyz/gaiko/internal/flags"
+	"github.com/taikoxyz/gaiko/internal/prover"
+	"github.com/taikoxyz/gaiko/internal/witness"
+	"github.com/taikoxyz/gaiko/tests/synth"
+)
+
+var (
+	// revertCode reverts every call.
+	revertCode = common.FromHex("0x60006000fd")
+	// destructCode self-destructs to the caller.
+	destructCode = common.FromHex("0x33ff")
+	// storeCode stores the call value at slot 0.
+	storeCode = common.FromHex("0x3460005500")
+)
+
+type synthAccount struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+func newSynthAccount(t *testing.T) *synthAccount {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &synthAccount{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func (a *synthAccount) tx(t *testing.T, nonce uint64, to common.Address, value int64) *types.Transaction {
+	return a.txOn(t, witness.TaikoHoodiNetwork, nonce, to, value)
+}
+
+func (a *synthAccount) txOn(
+	t *testing.T,
+	network witness.Network,
+	nonce uint64,
+	to common.Address,
+	value int64,
+) *types.Transaction {
+	spec, err := witness.LookupChainSpec(network)
+	require.NoError(t, err)
+	chainID := new(big.Int).SetUint64(spec.ChainID)
+	tx, err := types.SignNewTx(a.key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(params.GWei / 100),
+		GasFeeCap: big.NewInt(params.GWei),
+		Gas:       100_000,
+		To:        &to,
+		Value:     big.NewInt(value),
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestSynth(t *testing.T) {
+	alice, bob := newSynthAccount(t), newSynthAccount(t)
+	reverter := common.HexToAddress("0x1000")
+	destructor := common.HexToAddress("0x2000")
+	store := common.HexToAddress("0x3000")
+	alloc := types.GenesisAlloc{
+		alice.addr: {Balance: big.NewInt(params.Ether)},
+		reverter:   {Code: revertCode},
+		destructor: {
+			Code:    destructCode,
+			Balance: big.NewInt(params.GWei),
+			Storage: map[common.Hash]common.Hash{{}: common.HexToHash("0x01")},
+		},
+		store: {Code: storeCode},
+	}
+	invalid := []*types.Transaction{
+		alice.tx(t, 0, bob.addr, 1),
+		// nonce gap
+		alice.tx(t, 5, bob.addr, 1),
+		// no funds
+		bob.tx(t, 0, alice.addr, 1),
+		alice.tx(t, 1, bob.addr, 1),
+	}
+
+	tests := []struct {
+		name  string
+		cfg   func() *synth.Config
+		check func(t *testing.T, batch *synth.Batch)
+	}{
+		{
+			name: "empty blocks",
+			cfg: func() *synth.Config {
+				return &synth.Config{Blocks: []*synth.Block{{}, {TimeShift: 2}, {TimeShift: 0}}}
+			},
+			check: func(t *testing.T, batch *synth.Batch) {
+				for _, block := range batch.Blocks {
+					assert.Len(t, block.Transactions(), 1)
+				}
+			},
+		},
+		{
+			name: "transfers in blobs",
+			cfg: func() *synth.Config {
+				return &synth.Config{
+					Alloc: alloc,
+					Blocks: []*synth.Block{
+						{Txs: []*types.Transaction{alice.tx(t, 0, bob.addr, 1), alice.tx(t, 1, store, 2)}},
+						{Txs: []*types.Transaction{alice.tx(t, 2, store, 0)}, TimeShift: 1},
+					},
+					Blob:          true,
+					BlobProofType: witness.ProofOfEquivalence,
+				}
+			},
+			check: func(t *testing.T, batch *synth.Batch) {
+				assert.Len(t, batch.Input.Taiko.TxDataFromBlob, 1)
+				assert.Empty(t, batch.Input.Taiko.TxDataFromCalldata)
+				require.NoError(t, batch.Input.Verify(witness.SGXGethProofType))
+			},
+		},
+		{
+			name: "revert",
+			cfg: func() *synth.Config {
+				return &synth.Config{
+					Alloc:  alloc,
+					Blocks: []*synth.Block{{Txs: []*types.Transaction{alice.tx(t, 0, reverter, 1)}}},
+				}
+			},
+			check: func(t *testing.T, batch *synth.Batch) {
+				assert.Equal(t, types.ReceiptStatusFailed, batch.Receipts[0][1].Status)
+			},
+		},
+		{
+			name: "self-destruct",
+			cfg: func() *synth.Config {
+				return &synth.Config{
+					Alloc: alloc,
+					Blocks: []*synth.Block{
+						{Txs: []*types.Transaction{alice.tx(t, 0, destructor, 0)}},
+						{Txs: []*types.Transaction{alice.tx(t, 1, destructor, 1)}, TimeShift: 1},
+					},
+				}
+			},
+			check: func(t *testing.T, batch *synth.Batch) {
+				assert.Equal(t, types.ReceiptStatusSuccessful, batch.Receipts[0][1].Status)
+				// the second block sees the state without the contract
+				_, ok := batch.Input.Inputs[1].ParentStorage[destructor]
+				assert.False(t, ok)
+			},
+		},
+		{
+			name: "invalid txs",
+			cfg: func() *synth.Config {
+				return &synth.Config{
+					Alloc:  alloc,
+					Blocks: []*synth.Block{{Txs: invalid}},
+				}
+			},
+			check: func(t *testing.T, batch *synth.Batch) {
+				assert.Len(t, batch.Dropped, 2)
+				assert.Len(t, batch.Blocks[0].Transactions(), 3)
+				// gaiko decodes every proposed tx from the tx list, after
+				// the anchor
+				var pairs []*witness.Pair
+				for pair := range batch.Input.GuestInputs() {
+					pairs = append(pairs, pair)
+				}
+				require.Len(t, pairs, 1)
+				want := append(types.Transactions{batch.Blocks[0].Transactions()[0]}, invalid...)
+				require.Len(t, pairs[0].Txs, len(want))
+				for i, tx := range want {
+					assert.Equal(t, tx.Hash(), pairs[0].Txs[i].Hash(), "tx %d", i)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch, err := synth.Generate(tt.cfg())
+			require.NoError(t, err)
+			tt.check(t, batch)
+			assert.Equal(t, batch.Output, proveSynth(t, batch.Input, (*prover.SGXProver).BatchOneshot))
+		})
+	}
+}
+
+func TestSynthMaxBatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("generates a batch of 768 blocks")
+	}
+	blocks := make([]*synth.Block, 769)
+	for i := range blocks {
+		blocks[i] = &synth.Block{TimeShift: 1}
+	}
+	batch, err := synth.Generate(&synth.Config{Blocks: blocks[:768]})
+	require.NoError(t, err)
+	require.NoError(t, batch.Input.Verify(witness.SGXGethProofType))
+	assert.Equal(t, batch.Output, proveSynth(t, batch.Input, (*prover.SGXProver).BatchOneshot))
+
+	batch, err = synth.Generate(&synth.Config{Blocks: blocks})
+	require.NoError(t, err)
+	require.ErrorContains(t, batch.Input.Verify(witness.SGXGethProofType), "too many inputs")
+}
+
+func TestSynthChainSpecMismatch(t *testing.T) {
+	batch, err := synth.Generate(&synth.Config{Blocks: []*synth.Block{{}, {TimeShift: 1}}})
+	require.NoError(t, err)
+	spec := *batch.Input.Taiko.ChainSpec
+	spec.Name = witness.TaikoMainnetNetwork
+	batch.Input.Inputs[1].ChainSpec = &spec
+
+	require.ErrorContains(t, batch.Input.Verify(witness.SGXGethProofType), "unexpected name")
+	// debug mode skips Verify, the blocks still have to run on the batch chain
+	_, err = witness.NewPublicInput(batch.Input, witness.NativeProofType, "debug", common.Address{})
+	require.ErrorContains(t, err, "doesn't match the batch chain spec")
+}
+
+func TestSynthL1Block(t *testing.T) {
+	alice, bob := newSynthAccount(t), newSynthAccount(t)
+	store := common.HexToAddress("0x3000")
+	block, err := synth.GenerateL1(&synth.L1Config{
+		Alloc: types.GenesisAlloc{
+			alice.addr: {Balance: big.NewInt(params.Ether)},
+			store:      {Code: storeCode},
+		},
+		Txs: []*types.Transaction{
+			alice.txOn(t, witness.EthereumNetwork, 0, bob.addr, 1),
+			alice.txOn(t, witness.EthereumNetwork, 1, store, 2),
+			// signed for another chain
+			alice.tx(t, 2, bob.addr, 1),
+		},
+		Coinbase: common.HexToAddress("0xc0ffee"),
+	})
+	require.NoError(t, err)
+	assert.Len(t, block.Block.Transactions(), 2)
+	assert.Len(t, block.Dropped, 1)
+	require.NoError(t, block.Input.Verify(witness.NativeProofType))
+	assert.Equal(t, block.Output, proveSynth(t, block.Input, (*prover.SGXProver).L1BlockOneshot))
+
+	// a batch witness isn't an L1 block
+	batch, err := synth.Generate(&synth.Config{Blocks: []*synth.Block{{}}})
+	require.NoError(t, err)
+	input := &witness.L1BlockGuestInput{GuestInput: *batch.Input.Inputs[0]}
+	require.ErrorContains(t, input.Verify(witness.NativeProofType), "unexpected taiko chain spec")
+}
+
+// proveSynth proves a generated witness natively with oneshot and returns its
+// public input hash.
+func proveSynth(
+	t *testing.T,
+	input any,
+	oneshot func(*prover.SGXProver, context.Context, *flags.Arguments) error,
+) common.Hash {
+	data, err := json.Marshal(input)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	args := &flags.Arguments{
+		SGXType:       "debug",
+		ProofType:     witness.NativeProofType,
+		WitnessReader: bytes.NewReader(data),
+		ProofWriter:   &out,
+	}
+	require.NoError(t, oneshot(prover.NewSGXProver(args), context.Background(), args))
+	var output prover.ProofResponse
+	require.NoError(t, json.NewDecoder(&out).Decode(&output))
+	return output.Input
+}